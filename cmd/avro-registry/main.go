@@ -0,0 +1,228 @@
+/*
+Command avro-registry runs a local schema registry implementing the Confluent Schema Registry REST API.
+
+Schemas are assigned ids and checked for compatibility as they are registered. The registry state is
+kept in memory, or in a JSON file when the -file flag is given, so it survives restarts.
+
+The export command writes all schema versions of a registry to a directory, in the layout
+read by registry.DirRegistry.
+
+Usage:
+
+	avro-registry [-addr :8081] [-file registry.json] [-compatibility BACKWARD]
+	avro-registry export -url http://localhost:8081 -dir schemas [-username user -password pass] [-token token]
+*/
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hamba/avro/registry"
+	"github.com/hamba/avro/registry/server"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := export(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	addr := flag.String("addr", ":8081", "The address to listen on.")
+	file := flag.String("file", "", "The file to persist the registry state to. The state is kept in memory if empty.")
+	lvl := flag.String("compatibility", string(registry.Backward), "The global compatibility level of a new registry.")
+	flag.Parse()
+
+	h, err := newHandler(*file, registry.CompatibilityLevel(*lvl))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("avro-registry listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, h))
+}
+
+// export exports a registry to a directory.
+func export(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	baseURL := fs.String("url", "", "The url of the registry to export. Several urls may be separated by commas.")
+	dir := fs.String("dir", "", "The directory to export the registry to.")
+	username := fs.String("username", "", "The username to authenticate with.")
+	password := fs.String("password", "", "The password to authenticate with.")
+	token := fs.String("token", "", "The bearer token to authenticate with.")
+	_ = fs.Parse(args)
+
+	if *baseURL == "" || *dir == "" {
+		return errors.New("export requires -url and -dir")
+	}
+
+	var opts []registry.ClientFunc
+	if *username != "" || *password != "" {
+		opts = append(opts, registry.WithBasicAuth(*username, *password))
+	}
+	if *token != "" {
+		opts = append(opts, registry.WithBearerToken(*token))
+	}
+
+	client, err := registry.NewClient(*baseURL, opts...)
+	if err != nil {
+		return err
+	}
+
+	return registry.Export(context.Background(), client, *dir)
+}
+
+// newHandler returns the registry handler, loading the registry state from the file if it exists.
+// The compatibility level is only used when the registry is new.
+func newHandler(file string, lvl registry.CompatibilityLevel) (http.Handler, error) {
+	reg := server.NewRegistry()
+
+	b, err := ioutil.ReadFile(file)
+	switch {
+	case file == "" || os.IsNotExist(err):
+		if err = reg.SetGlobalCompatibilityLevel(lvl); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		if err = reg.Load(bytes.NewReader(b)); err != nil {
+			return nil, fmt.Errorf("unable to load %s: %v", file, err)
+		}
+	}
+
+	h := server.NewHandler(reg)
+	if file == "" {
+		return h, nil
+	}
+
+	return &persistHandler{h: h, reg: reg, file: file}, nil
+}
+
+// persistHandler saves the registry state to a file after each successful change.
+type persistHandler struct {
+	h    http.Handler
+	reg  *server.Registry
+	file string
+
+	mu sync.RWMutex
+}
+
+// ServeHTTP serves the request, saving the registry state if it changed.
+//
+// The response is only written once the state is saved. If saving fails, the change
+// is rolled back and an internal server error is returned instead.
+func (h *persistHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// Reads wait for pending changes, so they never see a change that is rolled back.
+	if req.Method == http.MethodGet {
+		h.mu.RLock()
+		defer h.mu.RUnlock()
+
+		h.h.ServeHTTP(w, req)
+		return
+	}
+
+	// Changes are serialised so the saved state always matches the response.
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	prev := &bytes.Buffer{}
+	if err := h.reg.Save(prev); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	buf := newResponseBuffer()
+	h.h.ServeHTTP(buf, req)
+
+	if buf.status < 300 {
+		if err := h.save(); err != nil {
+			log.Printf("unable to save registry state: %v", err)
+			if loadErr := h.reg.Load(prev); loadErr != nil {
+				log.Printf("unable to restore registry state: %v", loadErr)
+			}
+			writeError(w, fmt.Errorf("unable to save registry state: %v", err))
+			return
+		}
+	}
+
+	buf.writeTo(w)
+}
+
+func (h *persistHandler) save() error {
+	buf := &bytes.Buffer{}
+	if err := h.reg.Save(buf); err != nil {
+		return err
+	}
+
+	// The state is written to a temporary file first, so a failed write cannot corrupt it.
+	tmp, err := ioutil.TempFile(filepath.Dir(h.file), filepath.Base(h.file)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), h.file)
+}
+
+// responseBuffer buffers a response so it can be written later.
+type responseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseBuffer() *responseBuffer {
+	return &responseBuffer{header: http.Header{}, status: http.StatusOK}
+}
+
+// Header returns the buffered response headers.
+func (b *responseBuffer) Header() http.Header {
+	return b.header
+}
+
+// Write buffers the response body.
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+// WriteHeader buffers the response status code.
+func (b *responseBuffer) WriteHeader(status int) {
+	b.status = status
+}
+
+func (b *responseBuffer) writeTo(w http.ResponseWriter) {
+	for k, v := range b.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(b.status)
+	_, _ = w.Write(b.body.Bytes())
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	regErr := registry.Error{StatusCode: http.StatusInternalServerError, Code: registry.CodeBackendDataStore, Message: err.Error()}
+
+	w.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	w.WriteHeader(regErr.StatusCode)
+	_ = jsoniter.NewEncoder(w).Encode(regErr)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hamba/avro/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHandler_InMemory(t *testing.T) {
+	h, err := newHandler("", registry.Full)
+	if !assert.NoError(t, err) {
+		return
+	}
+	s := httptest.NewServer(h)
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	lvl, err := client.GetGlobalCompatibilityLevel()
+
+	assert.NoError(t, err)
+	assert.Equal(t, registry.Full, lvl)
+}
+
+func TestNewHandler_InvalidCompatibility(t *testing.T) {
+	_, err := newHandler("", "foo")
+
+	assert.Error(t, err)
+}
+
+func TestNewHandler_PersistsState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "avro-registry")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "registry.json")
+
+	h, err := newHandler(file, registry.Backward)
+	if !assert.NoError(t, err) {
+		return
+	}
+	s := httptest.NewServer(h)
+	client, _ := registry.NewClient(s.URL)
+	id, _, err := client.CreateSchema("foobar", `"int"`)
+	assert.NoError(t, err)
+	_, _, err = client.CreateSchema("foobar", `"string"`)
+	assert.Error(t, err)
+	s.Close()
+
+	h, err = newHandler(file, registry.None)
+	if !assert.NoError(t, err) {
+		return
+	}
+	s = httptest.NewServer(h)
+	defer s.Close()
+	client, _ = registry.NewClient(s.URL)
+
+	schema, err := client.GetSchema(id)
+	assert.NoError(t, err)
+	assert.Equal(t, `"int"`, schema.String())
+	lvl, err := client.GetGlobalCompatibilityLevel()
+	assert.NoError(t, err)
+	assert.Equal(t, registry.Backward, lvl)
+}
+
+func TestNewHandler_SaveError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "avro-registry")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "state", "registry.json")
+	_ = os.Mkdir(filepath.Dir(file), 0700)
+
+	h, err := newHandler(file, registry.Backward)
+	if !assert.NoError(t, err) {
+		return
+	}
+	s := httptest.NewServer(h)
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	_, _, err = client.CreateSchema("foo", `"int"`)
+	assert.NoError(t, err)
+	_ = os.RemoveAll(filepath.Dir(file))
+
+	_, _, err = client.CreateSchema("bar", `"int"`)
+
+	if assert.Error(t, err) {
+		assert.Equal(t, http.StatusInternalServerError, err.(registry.Error).StatusCode)
+	}
+	subjects, err := client.GetSubjects()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo"}, subjects)
+}
+
+func TestNewHandler_LoadError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "avro-registry")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "registry.json")
+	_ = ioutil.WriteFile(file, []byte("{"), 0600)
+
+	_, err = newHandler(file, registry.Backward)
+
+	assert.Error(t, err)
+}
+
+func TestExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "avro-registry")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	h, _ := newHandler("", registry.Backward)
+	s := httptest.NewServer(h)
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	_, _, _ = client.CreateSchema("foobar", `"int"`)
+
+	err = export([]string{"-url", s.URL, "-dir", dir})
+
+	assert.NoError(t, err)
+	reg, _ := registry.NewDirRegistry(dir)
+	schema, err := reg.GetLatestSchema("foobar")
+	assert.NoError(t, err)
+	assert.Equal(t, `"int"`, schema.String())
+}
+
+func TestExport_MissingFlags(t *testing.T) {
+	err := export([]string{"-url", "http://example.com"})
+
+	assert.Error(t, err)
+}
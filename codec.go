@@ -11,13 +11,15 @@ import (
 )
 
 var (
-	timeRType uintptr
-	ratRType  uintptr
+	timeRType     uintptr
+	ratRType      uintptr
+	durationRType uintptr
 )
 
 func init() {
 	timeRType = reflect2.TypeOf(time.Time{}).RType()
 	ratRType = reflect2.TypeOf(big.Rat{}).RType()
+	durationRType = reflect2.TypeOf(LogicalDuration{}).RType()
 }
 
 type null struct{}
@@ -86,6 +88,14 @@ func (c *frozenConfig) DecoderOf(schema Schema, typ reflect2.Type) ValDecoder {
 }
 
 func decoderOfType(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValDecoder {
+	if dec := createDecoderOfAvroMarshaler(cfg, schema, typ); dec != nil {
+		return dec
+	}
+
+	if dec := createDecoderOfLogical(cfg, schema, typ); dec != nil {
+		return dec
+	}
+
 	if dec := createDecoderOfMarshaler(cfg, schema, typ); dec != nil {
 		return dec
 	}
@@ -156,6 +166,14 @@ func (e *onePtrEncoder) Encode(ptr unsafe.Pointer, w *Writer) {
 }
 
 func encoderOfType(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValEncoder {
+	if enc := createEncoderOfAvroMarshaler(cfg, schema, typ); enc != nil {
+		return enc
+	}
+
+	if enc := createEncoderOfLogical(cfg, schema, typ); enc != nil {
+		return enc
+	}
+
 	if enc := createEncoderOfMarshaler(cfg, schema, typ); enc != nil {
 		return enc
 	}
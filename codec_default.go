@@ -0,0 +1,130 @@
+package avro
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+type defaultDecoder struct {
+	data    []byte
+	decoder ValDecoder
+}
+
+func (d *defaultDecoder) Decode(ptr unsafe.Pointer, r *Reader) {
+	reader := r.cfg.borrowReader(d.data)
+	d.decoder.Decode(ptr, reader)
+	if reader.Error != nil && reader.Error != io.EOF && r.Error == nil {
+		r.Error = reader.Error
+	}
+	r.cfg.returnReader(reader)
+}
+
+// encodeDefault returns the Avro encoding of the field default, or nil
+// if the field has an empty default.
+func encodeDefault(field *Field) ([]byte, error) {
+	def := field.Default()
+	if def == nil && field.Type().Type() != Union {
+		return nil, nil
+	}
+
+	w := NewWriter(nil, 64)
+	writeDefault(w, field.Type(), def)
+	if w.Error != nil {
+		return nil, fmt.Errorf("avro: invalid default for field %s: %s", field.Name(), w.Error.Error())
+	}
+
+	return w.Buffer(), nil
+}
+
+// writeDefault writes a validated default value in the given schema.
+func writeDefault(w *Writer, schema Schema, def interface{}) {
+	switch schema.Type() {
+	case Null:
+
+	case Boolean:
+		w.WriteBool(def.(bool))
+
+	case Int:
+		w.WriteInt(int32(def.(int)))
+
+	case Long:
+		w.WriteLong(def.(int64))
+
+	case Float:
+		w.WriteFloat(def.(float32))
+
+	case Double:
+		w.WriteDouble(def.(float64))
+
+	case String:
+		w.WriteString(def.(string))
+
+	case Bytes:
+		w.WriteBytes(defaultBytes(def.(string)))
+
+	case Fixed:
+		size := schema.(*FixedSchema).Size()
+		b := make([]byte, size)
+		copy(b, defaultBytes(def.(string)))
+		w.Write(b)
+
+	case Enum:
+		sym := def.(string)
+		for i, s := range schema.(*EnumSchema).Symbols() {
+			if s == sym {
+				w.WriteInt(int32(i))
+				return
+			}
+		}
+		w.Error = fmt.Errorf("unknown enum symbol %s", sym)
+
+	case Array:
+		items := schema.(*ArraySchema).Items()
+		arr := def.([]interface{})
+		if len(arr) > 0 {
+			w.WriteBlockHeader(int64(len(arr)), 0)
+			for _, v := range arr {
+				writeDefault(w, items, v)
+			}
+		}
+		w.WriteBlockHeader(0, 0)
+
+	case Map:
+		values := schema.(*MapSchema).Values()
+		m := def.(map[string]interface{})
+		if len(m) > 0 {
+			w.WriteBlockHeader(int64(len(m)), 0)
+			for k, v := range m {
+				w.WriteString(k)
+				writeDefault(w, values, v)
+			}
+		}
+		w.WriteBlockHeader(0, 0)
+
+	case Record:
+		m := def.(map[string]interface{})
+		for _, field := range schema.(*RecordSchema).Fields() {
+			writeDefault(w, field.Type(), m[field.Name()])
+		}
+
+	case Union:
+		// The default always corresponds to the first type in the union.
+		w.WriteLong(0)
+		writeDefault(w, schema.(*UnionSchema).Types()[0], def)
+
+	case Ref:
+		writeDefault(w, schema.(*RefSchema).Schema(), def)
+	}
+}
+
+// defaultBytes converts a bytes or fixed default, where each character
+// is a code point of an ISO-8859-1 byte, to bytes.
+func defaultBytes(s string) []byte {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		b = append(b, byte(r))
+	}
+
+	return b
+}
@@ -10,7 +10,7 @@ import (
 
 func createDecoderOfEnum(schema Schema, typ reflect2.Type) ValDecoder {
 	if typ.Kind() == reflect.String {
-		return &enumCodec{symbols: schema.(*EnumSchema).Symbols()}
+		return &enumCodec{symbols: schema.(*EnumSchema).decodedSymbols()}
 	}
 
 	return &errorDecoder{err: fmt.Errorf("avro: %s is unsupported for Avro %s", typ.String(), schema.Type())}
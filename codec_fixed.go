@@ -1,6 +1,7 @@
 package avro
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math"
 	"math/big"
@@ -20,13 +21,26 @@ func createDecoderOfFixed(schema Schema, typ reflect2.Type) ValDecoder {
 		}
 		return &fixedCodec{arrayType: typ.(*reflect2.UnsafeArrayType)}
 
+	case reflect.String:
+		ls := fixed.Logical()
+		if ls == nil || ls.Type() != UUID {
+			break
+		}
+		return &fixedUUIDCodec{}
+
 	case reflect.Struct:
 		ls := fixed.Logical()
-		if typ.RType() != ratRType || ls == nil || ls.Type() != Decimal {
+		if ls == nil {
 			break
 		}
-		dec := ls.(*DecimalLogicalSchema)
-		return &fixedDecimalCodec{prec: dec.Precision(), scale: dec.Scale(), size: fixed.Size()}
+		switch {
+		case typ.RType() == ratRType && ls.Type() == Decimal:
+			dec := ls.(*DecimalLogicalSchema)
+			return &fixedDecimalCodec{prec: dec.Precision(), scale: dec.Scale(), size: fixed.Size()}
+
+		case typ.RType() == durationRType && ls.Type() == Duration:
+			return &fixedDurationCodec{}
+		}
 	}
 
 	return &errorDecoder{err: fmt.Errorf("avro: %s is unsupported for Avro %s", typ.String(), schema.Type())}
@@ -43,6 +57,20 @@ func createEncoderOfFixed(schema Schema, typ reflect2.Type) ValEncoder {
 		}
 		return &fixedCodec{arrayType: typ.(*reflect2.UnsafeArrayType)}
 
+	case reflect.String:
+		ls := fixed.Logical()
+		if ls == nil || ls.Type() != UUID {
+			break
+		}
+		return &fixedUUIDCodec{}
+
+	case reflect.Struct:
+		ls := fixed.Logical()
+		if typ.RType() != durationRType || ls == nil || ls.Type() != Duration {
+			break
+		}
+		return &fixedDurationCodec{}
+
 	case reflect.Ptr:
 		ptrType := typ.(*reflect2.UnsafePtrType)
 		elemType := ptrType.Elem()
@@ -75,6 +103,51 @@ func (c *fixedCodec) Encode(ptr unsafe.Pointer, w *Writer) {
 	}
 }
 
+type fixedUUIDCodec struct{}
+
+func (c *fixedUUIDCodec) Decode(ptr unsafe.Pointer, r *Reader) {
+	var u [16]byte
+	r.Read(u[:])
+	*((*string)(ptr)) = formatUUID(u)
+}
+
+func (c *fixedUUIDCodec) Encode(ptr unsafe.Pointer, w *Writer) {
+	u, err := parseUUID(*((*string)(ptr)))
+	if err != nil {
+		w.Error = err
+		return
+	}
+	w.Write(u[:])
+}
+
+type fixedDurationCodec struct{}
+
+func (c *fixedDurationCodec) Decode(ptr unsafe.Pointer, r *Reader) {
+	*((*LogicalDuration)(ptr)) = readDuration(r)
+}
+
+func (c *fixedDurationCodec) Encode(ptr unsafe.Pointer, w *Writer) {
+	d := *((*LogicalDuration)(ptr))
+
+	b := make([]byte, 12)
+	binary.LittleEndian.PutUint32(b[0:], d.Months)
+	binary.LittleEndian.PutUint32(b[4:], d.Days)
+	binary.LittleEndian.PutUint32(b[8:], d.Milliseconds)
+	w.Write(b)
+}
+
+// readDuration reads a duration, three little-endian unsigned 32 bit integers.
+func readDuration(r *Reader) LogicalDuration {
+	b := make([]byte, 12)
+	r.Read(b)
+
+	return LogicalDuration{
+		Months:       binary.LittleEndian.Uint32(b[0:]),
+		Days:         binary.LittleEndian.Uint32(b[4:]),
+		Milliseconds: binary.LittleEndian.Uint32(b[8:]),
+	}
+}
+
 type fixedDecimalCodec struct {
 	prec  int
 	scale int
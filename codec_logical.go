@@ -0,0 +1,42 @@
+package avro
+
+import (
+	"github.com/modern-go/reflect2"
+)
+
+// LogicalTypeCodec creates the codecs of Go types for a custom logical type.
+type LogicalTypeCodec interface {
+	// DecoderOf returns the decoder of the Go type for the schema, or nil if the type is not supported.
+	DecoderOf(schema Schema, typ reflect2.Type) ValDecoder
+
+	// EncoderOf returns the encoder of the Go type for the schema, or nil if the type is not supported.
+	EncoderOf(schema Schema, typ reflect2.Type) ValEncoder
+}
+
+func createDecoderOfLogical(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValDecoder {
+	codec := logicalTypeCodec(cfg, schema)
+	if codec == nil {
+		return nil
+	}
+	return codec.DecoderOf(schema, typ)
+}
+
+func createEncoderOfLogical(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValEncoder {
+	codec := logicalTypeCodec(cfg, schema)
+	if codec == nil {
+		return nil
+	}
+	return codec.EncoderOf(schema, typ)
+}
+
+func logicalTypeCodec(cfg *frozenConfig, schema Schema) LogicalTypeCodec {
+	if len(cfg.config.LogicalTypes) == 0 {
+		return nil
+	}
+
+	ls := getLogicalSchema(schema)
+	if ls == nil {
+		return nil
+	}
+	return cfg.config.LogicalTypes[ls.Type()]
+}
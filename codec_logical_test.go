@@ -0,0 +1,249 @@
+package avro_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+	"unsafe"
+
+	"github.com/hamba/avro"
+	"github.com/modern-go/reflect2"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	avro.RegisterLogicalType("test-money", []avro.Type{avro.Bytes}, func(_ avro.Type, props map[string]interface{}) avro.LogicalSchema {
+		currency, ok := props["currency"].(string)
+		if !ok {
+			return nil
+		}
+		return TestMoneyLogicalSchema{Currency: currency}
+	})
+	avro.RegisterLogicalType("test-geo-point", []avro.Type{avro.Fixed}, nil)
+}
+
+func TestRegisterLogicalType(t *testing.T) {
+	tests := []struct {
+		name        string
+		schema      string
+		wantLogical bool
+		want        string
+	}{
+		{
+			name:        "Primitive",
+			schema:      `{"type":"bytes","logicalType":"test-money","currency":"EUR"}`,
+			wantLogical: true,
+			want:        `{"type":"bytes","logicalType":"test-money","currency":"EUR"}`,
+		},
+		{
+			name:        "Primitive Invalid Properties",
+			schema:      `{"type":"bytes","logicalType":"test-money"}`,
+			wantLogical: false,
+			want:        `"bytes"`,
+		},
+		{
+			name:        "Primitive Invalid Type",
+			schema:      `{"type":"string","logicalType":"test-money","currency":"EUR"}`,
+			wantLogical: false,
+			want:        `"string"`,
+		},
+		{
+			name:        "Fixed",
+			schema:      `{"type":"fixed","name":"point","size":16,"logicalType":"test-geo-point"}`,
+			wantLogical: true,
+			want:        `{"name":"point","type":"fixed","size":16,"logicalType":"test-geo-point"}`,
+		},
+		{
+			name:        "Unregistered",
+			schema:      `{"type":"bytes","logicalType":"test-unknown"}`,
+			wantLogical: false,
+			want:        `"bytes"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := avro.Parse(tt.schema)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLogical, schema.(avro.LogicalTypeSchema).Logical() != nil)
+			assert.Equal(t, tt.want, schema.String())
+		})
+	}
+}
+
+func TestDecoder_LogicalTypeCodec(t *testing.T) {
+	defer ConfigTeardown()
+
+	api := avro.Config{
+		LogicalTypes: map[avro.LogicalType]avro.LogicalTypeCodec{"test-money": TestMoneyCodec{}},
+	}.Freeze()
+	data := []byte{0x08, 0x31, 0x32, 0x33, 0x34}
+	schema := avro.MustParse(`{"type":"bytes","logicalType":"test-money","currency":"EUR"}`)
+
+	var got TestMoney
+	err := api.Unmarshal(schema, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestMoney{Cents: 1234, Currency: "EUR"}, got)
+}
+
+func TestDecoder_LogicalTypeCodecUnsupportedType(t *testing.T) {
+	defer ConfigTeardown()
+
+	api := avro.Config{
+		LogicalTypes: map[avro.LogicalType]avro.LogicalTypeCodec{"test-money": TestMoneyCodec{}},
+	}.Freeze()
+	data := []byte{0x08, 0x31, 0x32, 0x33, 0x34}
+	schema := avro.MustParse(`{"type":"bytes","logicalType":"test-money","currency":"EUR"}`)
+
+	var got []byte
+	err := api.Unmarshal(schema, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1234"), got)
+}
+
+func TestEncoder_LogicalTypeCodec(t *testing.T) {
+	defer ConfigTeardown()
+
+	api := avro.Config{
+		LogicalTypes: map[avro.LogicalType]avro.LogicalTypeCodec{"test-money": TestMoneyCodec{}},
+	}.Freeze()
+	schema := avro.MustParse(`{"type":"bytes","logicalType":"test-money","currency":"EUR"}`)
+
+	got, err := api.Marshal(schema, TestMoney{Cents: 1234, Currency: "EUR"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x08, 0x31, 0x32, 0x33, 0x34}, got)
+}
+
+func TestEncoder_LogicalTypeCodecError(t *testing.T) {
+	defer ConfigTeardown()
+
+	api := avro.Config{
+		LogicalTypes: map[avro.LogicalType]avro.LogicalTypeCodec{"test-money": TestMoneyCodec{}},
+	}.Freeze()
+	schema := avro.MustParse(`{"type":"bytes","logicalType":"test-money","currency":"EUR"}`)
+
+	_, err := api.Marshal(schema, TestMoney{Cents: 1234, Currency: "USD"})
+
+	assert.Error(t, err)
+}
+
+func TestEncoder_LogicalTypeCodecFixed(t *testing.T) {
+	defer ConfigTeardown()
+
+	api := avro.Config{
+		LogicalTypes: map[avro.LogicalType]avro.LogicalTypeCodec{"test-geo-point": TestGeoPointCodec{}},
+	}.Freeze()
+	schema := avro.MustParse(`{"type":"fixed","name":"point","size":16,"logicalType":"test-geo-point"}`)
+	point := TestGeoPoint{Lat: 52.37, Lon: 4.89}
+
+	b, err := api.Marshal(schema, point)
+	assert.NoError(t, err)
+
+	var got TestGeoPoint
+	err = api.Unmarshal(schema, b, &got)
+
+	assert.NoError(t, err)
+	assert.Len(t, b, 16)
+	assert.Equal(t, point, got)
+}
+
+type TestMoneyLogicalSchema struct {
+	Currency string
+}
+
+func (s TestMoneyLogicalSchema) Type() avro.LogicalType {
+	return "test-money"
+}
+
+func (s TestMoneyLogicalSchema) String() string {
+	return `"logicalType":"test-money","currency":"` + s.Currency + `"`
+}
+
+type TestMoney struct {
+	Cents    int64
+	Currency string
+}
+
+type TestMoneyCodec struct{}
+
+func (TestMoneyCodec) DecoderOf(schema avro.Schema, typ reflect2.Type) avro.ValDecoder {
+	if typ.RType() != reflect2.RTypeOf(TestMoney{}) {
+		return nil
+	}
+	return testMoneyCodec{currency: schema.(avro.LogicalTypeSchema).Logical().(TestMoneyLogicalSchema).Currency}
+}
+
+func (TestMoneyCodec) EncoderOf(schema avro.Schema, typ reflect2.Type) avro.ValEncoder {
+	if typ.RType() != reflect2.RTypeOf(TestMoney{}) {
+		return nil
+	}
+	return testMoneyCodec{currency: schema.(avro.LogicalTypeSchema).Logical().(TestMoneyLogicalSchema).Currency}
+}
+
+type testMoneyCodec struct {
+	currency string
+}
+
+func (c testMoneyCodec) Decode(ptr unsafe.Pointer, r *avro.Reader) {
+	cents, err := strconv.ParseInt(string(r.ReadBytes()), 10, 64)
+	if err != nil {
+		r.ReportError("testMoneyCodec", err.Error())
+		return
+	}
+	*((*TestMoney)(ptr)) = TestMoney{Cents: cents, Currency: c.currency}
+}
+
+func (c testMoneyCodec) Encode(ptr unsafe.Pointer, w *avro.Writer) {
+	m := *((*TestMoney)(ptr))
+	if m.Currency != c.currency {
+		w.Error = fmt.Errorf("unexpected currency %s", m.Currency)
+		return
+	}
+	w.WriteBytes([]byte(strconv.FormatInt(m.Cents, 10)))
+}
+
+type TestGeoPoint struct {
+	Lat float64
+	Lon float64
+}
+
+type TestGeoPointCodec struct{}
+
+func (TestGeoPointCodec) DecoderOf(_ avro.Schema, typ reflect2.Type) avro.ValDecoder {
+	if typ.RType() != reflect2.RTypeOf(TestGeoPoint{}) {
+		return nil
+	}
+	return testGeoPointCodec{}
+}
+
+func (TestGeoPointCodec) EncoderOf(_ avro.Schema, typ reflect2.Type) avro.ValEncoder {
+	if typ.RType() != reflect2.RTypeOf(TestGeoPoint{}) {
+		return nil
+	}
+	return testGeoPointCodec{}
+}
+
+type testGeoPointCodec struct{}
+
+func (testGeoPointCodec) Decode(ptr unsafe.Pointer, r *avro.Reader) {
+	b := make([]byte, 16)
+	r.Read(b)
+	*((*TestGeoPoint)(ptr)) = TestGeoPoint{
+		Lat: math.Float64frombits(binary.BigEndian.Uint64(b[0:])),
+		Lon: math.Float64frombits(binary.BigEndian.Uint64(b[8:])),
+	}
+}
+
+func (testGeoPointCodec) Encode(ptr unsafe.Pointer, w *avro.Writer) {
+	p := *((*TestGeoPoint)(ptr))
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[0:], math.Float64bits(p.Lat))
+	binary.BigEndian.PutUint64(b[8:], math.Float64bits(p.Lon))
+	w.Write(b)
+}
@@ -2,38 +2,156 @@ package avro
 
 import (
 	"encoding"
+	"errors"
+	"fmt"
 	"unsafe"
 
 	"github.com/modern-go/reflect2"
 )
 
 var (
-	textMarshalerType   = reflect2.TypeOfPtr((*encoding.TextMarshaler)(nil)).Elem()
-	textUnmarshalerType = reflect2.TypeOfPtr((*encoding.TextUnmarshaler)(nil)).Elem()
+	marshalerType         = reflect2.TypeOfPtr((*Marshaler)(nil)).Elem()
+	unmarshalerType       = reflect2.TypeOfPtr((*Unmarshaler)(nil)).Elem()
+	textMarshalerType     = reflect2.TypeOfPtr((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType   = reflect2.TypeOfPtr((*encoding.TextUnmarshaler)(nil)).Elem()
+	binaryMarshalerType   = reflect2.TypeOfPtr((*encoding.BinaryMarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect2.TypeOfPtr((*encoding.BinaryUnmarshaler)(nil)).Elem()
 )
 
-func createDecoderOfMarshaler(_ *frozenConfig, schema Schema, typ reflect2.Type) ValDecoder {
-	if typ.Implements(textUnmarshalerType) && schema.Type() == String {
-		return &textMarshalerCodec{typ}
+// Marshaler is the interface implemented by types that can write their own Avro encoding.
+type Marshaler interface {
+	// MarshalAvro writes the Avro encoding of the value for the schema to w.
+	MarshalAvro(schema Schema, w *Writer) error
+}
+
+// Unmarshaler is the interface implemented by types that can read their own Avro encoding.
+type Unmarshaler interface {
+	// UnmarshalAvro reads the Avro encoding of the value for the schema from r.
+	UnmarshalAvro(schema Schema, r *Reader) error
+}
+
+func createDecoderOfAvroMarshaler(_ *frozenConfig, schema Schema, typ reflect2.Type) ValDecoder {
+	// Unions and references are resolved first, the type is given the schema they resolve to.
+	if schema.Type() == Union || schema.Type() == Ref {
+		return nil
+	}
+
+	if typ.Implements(unmarshalerType) {
+		return &avroMarshalerCodec{typ: typ, schema: schema}
 	}
 	ptrType := reflect2.PtrTo(typ)
-	if ptrType.Implements(textUnmarshalerType) && schema.Type() == String {
+	if ptrType.Implements(unmarshalerType) {
 		return &referenceDecoder{
-			&textMarshalerCodec{ptrType},
+			&avroMarshalerCodec{typ: ptrType, schema: schema},
+		}
+	}
+	return nil
+}
+
+func createEncoderOfAvroMarshaler(_ *frozenConfig, schema Schema, typ reflect2.Type) ValEncoder {
+	// Unions and references are resolved first, the type is given the schema they resolve to.
+	if schema.Type() == Union || schema.Type() == Ref {
+		return nil
+	}
+
+	if typ.Implements(marshalerType) {
+		return &avroMarshalerCodec{typ: typ, schema: schema}
+	}
+	ptrType := reflect2.PtrTo(typ)
+	if ptrType.Implements(marshalerType) {
+		return &referenceEncoder{
+			&avroMarshalerCodec{typ: ptrType, schema: schema},
+		}
+	}
+	return nil
+}
+
+func createDecoderOfMarshaler(_ *frozenConfig, schema Schema, typ reflect2.Type) ValDecoder {
+	switch schema.Type() {
+	case String:
+		if typ.Implements(textUnmarshalerType) {
+			return &textMarshalerCodec{typ}
+		}
+		ptrType := reflect2.PtrTo(typ)
+		if ptrType.Implements(textUnmarshalerType) {
+			return &referenceDecoder{
+				&textMarshalerCodec{ptrType},
+			}
+		}
+
+	case Bytes, Fixed:
+		size := -1
+		if fixed, ok := schema.(*FixedSchema); ok {
+			size = fixed.Size()
+		}
+
+		if typ.Implements(binaryUnmarshalerType) {
+			return &binaryMarshalerCodec{typ: typ, size: size}
+		}
+		ptrType := reflect2.PtrTo(typ)
+		if ptrType.Implements(binaryUnmarshalerType) {
+			return &referenceDecoder{
+				&binaryMarshalerCodec{typ: ptrType, size: size},
+			}
 		}
 	}
 	return nil
 }
 
 func createEncoderOfMarshaler(_ *frozenConfig, schema Schema, typ reflect2.Type) ValEncoder {
-	if typ.Implements(textMarshalerType) && schema.Type() == String {
-		return &textMarshalerCodec{
-			typ: typ,
+	switch schema.Type() {
+	case String:
+		if typ.Implements(textMarshalerType) {
+			return &textMarshalerCodec{
+				typ: typ,
+			}
+		}
+
+	case Bytes, Fixed:
+		size := -1
+		if fixed, ok := schema.(*FixedSchema); ok {
+			size = fixed.Size()
+		}
+
+		if typ.Implements(binaryMarshalerType) {
+			return &binaryMarshalerCodec{typ: typ, size: size}
 		}
 	}
 	return nil
 }
 
+type avroMarshalerCodec struct {
+	typ    reflect2.Type
+	schema Schema
+}
+
+func (c avroMarshalerCodec) Decode(ptr unsafe.Pointer, r *Reader) {
+	obj := c.typ.UnsafeIndirect(ptr)
+	if reflect2.IsNil(obj) {
+		ptrType := c.typ.(*reflect2.UnsafePtrType)
+		newPtr := ptrType.Elem().UnsafeNew()
+		*((*unsafe.Pointer)(ptr)) = newPtr
+		obj = c.typ.UnsafeIndirect(ptr)
+	}
+	unmarshaler := (obj).(Unmarshaler)
+	err := unmarshaler.UnmarshalAvro(c.schema, r)
+	if err != nil {
+		r.ReportError("avroMarshalerCodec", err.Error())
+	}
+}
+
+func (c avroMarshalerCodec) Encode(ptr unsafe.Pointer, w *Writer) {
+	obj := c.typ.UnsafeIndirect(ptr)
+	if c.typ.IsNullable() && reflect2.IsNil(obj) {
+		w.Error = errors.New("avro: cannot encode nil pointer")
+		return
+	}
+	marshaler := (obj).(Marshaler)
+	if err := marshaler.MarshalAvro(c.schema, w); err != nil {
+		w.Error = err
+	}
+}
+
 type textMarshalerCodec struct {
 	typ reflect2.Type
 }
@@ -68,3 +186,65 @@ func (c textMarshalerCodec) Encode(ptr unsafe.Pointer, w *Writer) {
 	}
 	w.WriteBytes(b)
 }
+
+// binaryMarshalerCodec en/decodes a binary marshaler as bytes, or as a fixed
+// when the size is not negative.
+type binaryMarshalerCodec struct {
+	typ  reflect2.Type
+	size int
+}
+
+func (c binaryMarshalerCodec) Decode(ptr unsafe.Pointer, r *Reader) {
+	obj := c.typ.UnsafeIndirect(ptr)
+	if reflect2.IsNil(obj) {
+		ptrType := c.typ.(*reflect2.UnsafePtrType)
+		newPtr := ptrType.Elem().UnsafeNew()
+		*((*unsafe.Pointer)(ptr)) = newPtr
+		obj = c.typ.UnsafeIndirect(ptr)
+	}
+	unmarshaler := (obj).(encoding.BinaryUnmarshaler)
+
+	var b []byte
+	if c.size < 0 {
+		b = r.ReadBytes()
+	} else {
+		b = make([]byte, c.size)
+		r.Read(b)
+	}
+	if r.Error != nil {
+		return
+	}
+
+	err := unmarshaler.UnmarshalBinary(b)
+	if err != nil {
+		r.ReportError("binaryMarshalerCodec", err.Error())
+	}
+}
+
+func (c binaryMarshalerCodec) Encode(ptr unsafe.Pointer, w *Writer) {
+	obj := c.typ.UnsafeIndirect(ptr)
+	if c.typ.IsNullable() && reflect2.IsNil(obj) {
+		if c.size < 0 {
+			w.WriteBytes(nil)
+			return
+		}
+		w.Error = errors.New("avro: cannot encode nil pointer")
+		return
+	}
+	marshaler := (obj).(encoding.BinaryMarshaler)
+	b, err := marshaler.MarshalBinary()
+	if err != nil {
+		w.Error = err
+		return
+	}
+
+	if c.size < 0 {
+		w.WriteBytes(b)
+		return
+	}
+	if len(b) != c.size {
+		w.Error = fmt.Errorf("avro: binary marshaler returned %d bytes for fixed of size %d", len(b), c.size)
+		return
+	}
+	w.Write(b)
+}
@@ -2,7 +2,9 @@ package avro_test
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -116,6 +118,178 @@ func TestEncoder_TextMarshalerError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDecoder_AvroUnmarshaler(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x0A, 0x31, 0x32, 0x2E, 0x33, 0x34}
+	schema := "string"
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got TestCents
+	err = dec.Decode(&got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestCents(1234), got)
+}
+
+func TestDecoder_AvroUnmarshalerNullableUnion(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x02, 0x0A, 0x31, 0x32, 0x2E, 0x33, 0x34}
+	schema := `["null","string"]`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got *TestCents
+	err = dec.Decode(&got)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, TestCents(1234), *got)
+	}
+}
+
+func TestDecoder_AvroUnmarshalerError(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x06, 0x66, 0x6F, 0x6F}
+	schema := "string"
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got TestCents
+	err = dec.Decode(&got)
+
+	assert.Error(t, err)
+}
+
+func TestEncoder_AvroMarshaler(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := "string"
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode(TestCents(1234))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x0A, 0x31, 0x32, 0x2E, 0x33, 0x34}, buf.Bytes())
+}
+
+func TestEncoder_AvroMarshalerPtrReceiver(t *testing.T) {
+	defer ConfigTeardown()
+
+	type TestRecord struct {
+		A TestCentsPtr `avro:"a"`
+	}
+
+	schema := `{"type":"record","name":"test","fields":[{"name":"a","type":"string"}]}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode(TestRecord{A: 1234})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x0A, 0x31, 0x32, 0x2E, 0x33, 0x34}, buf.Bytes())
+}
+
+func TestEncoder_AvroMarshalerError(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := "int"
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode(TestCents(1234))
+
+	assert.Error(t, err)
+}
+
+func TestDecoder_BinaryUnmarshaler(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		schema string
+	}{
+		{
+			name:   "Bytes",
+			data:   []byte{0x08, 0x00, 0x01, 0x00, 0x02},
+			schema: "bytes",
+		},
+		{
+			name:   "Fixed",
+			data:   []byte{0x00, 0x01, 0x00, 0x02},
+			schema: `{"type":"fixed","name":"test","size":4}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer ConfigTeardown()
+
+			dec, err := avro.NewDecoder(tt.schema, bytes.NewReader(tt.data))
+			assert.NoError(t, err)
+
+			var got TestPair
+			err = dec.Decode(&got)
+
+			assert.NoError(t, err)
+			assert.Equal(t, TestPair{A: 1, B: 2}, got)
+		})
+	}
+}
+
+func TestEncoder_BinaryMarshaler(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   []byte
+	}{
+		{
+			name:   "Bytes",
+			schema: "bytes",
+			want:   []byte{0x08, 0x00, 0x01, 0x00, 0x02},
+		},
+		{
+			name:   "Fixed",
+			schema: `{"type":"fixed","name":"test","size":4}`,
+			want:   []byte{0x00, 0x01, 0x00, 0x02},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer ConfigTeardown()
+
+			buf := bytes.NewBuffer([]byte{})
+			enc, err := avro.NewEncoder(tt.schema, buf)
+			assert.NoError(t, err)
+
+			err = enc.Encode(TestPair{A: 1, B: 2})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.Bytes())
+		})
+	}
+}
+
+func TestEncoder_BinaryMarshalerInvalidFixedSize(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{"type":"fixed","name":"test","size":6}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode(TestPair{A: 1, B: 2})
+
+	assert.Error(t, err)
+}
+
 type TestTimestamp time.Time
 
 func (t TestTimestamp) MarshalText() ([]byte, error) {
@@ -141,3 +315,49 @@ func (t *TestTimestampError) UnmarshalText(data []byte) error {
 func (t *TestTimestampError) MarshalText() ([]byte, error) {
 	return nil, errors.New("test")
 }
+
+type TestCents int64
+
+func (c TestCents) MarshalAvro(schema avro.Schema, w *avro.Writer) error {
+	if schema.Type() != avro.String {
+		return errors.New("test")
+	}
+	w.WriteString(fmt.Sprintf("%d.%02d", c/100, c%100))
+	return nil
+}
+
+func (c *TestCents) UnmarshalAvro(schema avro.Schema, r *avro.Reader) error {
+	var units, cents int64
+	if _, err := fmt.Sscanf(r.ReadString(), "%d.%d", &units, &cents); err != nil {
+		return err
+	}
+	*c = TestCents(units*100 + cents)
+	return nil
+}
+
+type TestCentsPtr int64
+
+func (c *TestCentsPtr) MarshalAvro(schema avro.Schema, w *avro.Writer) error {
+	return TestCents(*c).MarshalAvro(schema, w)
+}
+
+type TestPair struct {
+	A uint16
+	B uint16
+}
+
+func (p TestPair) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint16(b[0:], p.A)
+	binary.BigEndian.PutUint16(b[2:], p.B)
+	return b, nil
+}
+
+func (p *TestPair) UnmarshalBinary(b []byte) error {
+	if len(b) != 4 {
+		return errors.New("test")
+	}
+	p.A = binary.BigEndian.Uint16(b[0:])
+	p.B = binary.BigEndian.Uint16(b[2:])
+	return nil
+}
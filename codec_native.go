@@ -1,6 +1,7 @@
 package avro
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math"
 	"math/big"
@@ -64,12 +65,29 @@ func createDecoderOfNative(schema Schema, typ reflect2.Type) ValDecoder {
 		if schema.Type() != Float {
 			break
 		}
+		switch getEncodedType(schema) {
+		case Int:
+			return &float32IntCodec{}
+
+		case Long:
+			return &float32LongCodec{}
+		}
 		return &float32Codec{}
 
 	case reflect.Float64:
 		if schema.Type() != Double {
 			break
 		}
+		switch getEncodedType(schema) {
+		case Int:
+			return &float64IntCodec{}
+
+		case Long:
+			return &float64LongCodec{}
+
+		case Float:
+			return &float64FloatCodec{}
+		}
 		return &float64Codec{}
 
 	case reflect.String:
@@ -84,6 +102,12 @@ func createDecoderOfNative(schema Schema, typ reflect2.Type) ValDecoder {
 		}
 		return &bytesCodec{sliceType: typ.(*reflect2.UnsafeSliceType)}
 
+	case reflect.Array:
+		if !isUUIDArray(typ) || schema.Type() != String || getLogicalType(schema) != UUID {
+			break
+		}
+		return &uuidCodec{}
+
 	case reflect.Struct:
 		st := schema.Type()
 		ls := getLogicalSchema(schema)
@@ -98,6 +122,18 @@ func createDecoderOfNative(schema Schema, typ reflect2.Type) ValDecoder {
 		case typ.RType() == timeRType && st == Long && lt == TimestampMicros:
 			return &timestampMicrosCodec{}
 
+		case typ.RType() == timeRType && st == Long && lt == TimestampNanos:
+			return &timestampNanosCodec{}
+
+		case typ.RType() == timeRType && st == Long && lt == LocalTimestampMillis:
+			return &timestampMillisCodec{local: true}
+
+		case typ.RType() == timeRType && st == Long && lt == LocalTimestampMicros:
+			return &timestampMicrosCodec{local: true}
+
+		case typ.RType() == timeRType && st == Long && lt == LocalTimestampNanos:
+			return &timestampNanosCodec{local: true}
+
 		case typ.RType() == ratRType && st == Bytes && lt == Decimal:
 			dec := ls.(*DecimalLogicalSchema)
 
@@ -182,6 +218,9 @@ func createEncoderOfNative(schema Schema, typ reflect2.Type) ValEncoder {
 		if schema.Type() != String {
 			break
 		}
+		if getLogicalType(schema) == UUID {
+			return &uuidStringCodec{}
+		}
 		return &stringCodec{}
 
 	case reflect.Slice:
@@ -190,6 +229,12 @@ func createEncoderOfNative(schema Schema, typ reflect2.Type) ValEncoder {
 		}
 		return &bytesCodec{sliceType: typ.(*reflect2.UnsafeSliceType)}
 
+	case reflect.Array:
+		if !isUUIDArray(typ) || schema.Type() != String || getLogicalType(schema) != UUID {
+			break
+		}
+		return &uuidCodec{}
+
 	case reflect.Struct:
 		st := schema.Type()
 		lt := getLogicalType(schema)
@@ -203,6 +248,18 @@ func createEncoderOfNative(schema Schema, typ reflect2.Type) ValEncoder {
 		case typ.RType() == timeRType && st == Long && lt == TimestampMicros:
 			return &timestampMicrosCodec{}
 
+		case typ.RType() == timeRType && st == Long && lt == TimestampNanos:
+			return &timestampNanosCodec{}
+
+		case typ.RType() == timeRType && st == Long && lt == LocalTimestampMillis:
+			return &timestampMillisCodec{local: true}
+
+		case typ.RType() == timeRType && st == Long && lt == LocalTimestampMicros:
+			return &timestampMicrosCodec{local: true}
+
+		case typ.RType() == timeRType && st == Long && lt == LocalTimestampNanos:
+			return &timestampNanosCodec{local: true}
+
 		default:
 			break
 		}
@@ -230,6 +287,40 @@ func createEncoderOfNative(schema Schema, typ reflect2.Type) ValEncoder {
 	return &errorEncoder{err: fmt.Errorf("avro: %s is unsupported for Avro %s", typ.String(), schema.Type())}
 }
 
+func isUUIDArray(typ reflect2.Type) bool {
+	arrayType := typ.(reflect2.ArrayType)
+	return arrayType.Elem().Kind() == reflect.Uint8 && arrayType.Len() == 16
+}
+
+// parseUUID parses a uuid in its canonical textual form, xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func parseUUID(s string) ([16]byte, error) {
+	var u [16]byte
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("avro: invalid uuid %q", s)
+	}
+
+	b := []byte(s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:])
+	if _, err := hex.Decode(u[:], b); err != nil {
+		return u, fmt.Errorf("avro: invalid uuid %q", s)
+	}
+	return u, nil
+}
+
+// formatUUID returns the canonical textual form of the uuid.
+func formatUUID(u [16]byte) string {
+	b := make([]byte, 36)
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b)
+}
+
 func getLogicalSchema(schema Schema) LogicalSchema {
 	lts, ok := schema.(LogicalTypeSchema)
 	if !ok {
@@ -248,6 +339,16 @@ func getLogicalType(schema Schema) LogicalType {
 	return ls.Type()
 }
 
+// getEncodedType returns the type the schema was encoded with. This only
+// differs from the schema type when a writer type was promoted.
+func getEncodedType(schema Schema) Type {
+	if ps, ok := schema.(*PrimitiveSchema); ok && ps.encodedType != "" {
+		return ps.encodedType
+	}
+
+	return schema.Type()
+}
+
 type nullCodec struct{}
 
 func (*nullCodec) Encode(ptr unsafe.Pointer, w *Writer) {}
@@ -328,6 +429,18 @@ func (*float32Codec) Encode(ptr unsafe.Pointer, w *Writer) {
 	w.WriteFloat(*((*float32)(ptr)))
 }
 
+type float32IntCodec struct{}
+
+func (*float32IntCodec) Decode(ptr unsafe.Pointer, r *Reader) {
+	*((*float32)(ptr)) = float32(r.ReadInt())
+}
+
+type float32LongCodec struct{}
+
+func (*float32LongCodec) Decode(ptr unsafe.Pointer, r *Reader) {
+	*((*float32)(ptr)) = float32(r.ReadLong())
+}
+
 type float32DoubleCodec struct{}
 
 func (*float32DoubleCodec) Encode(ptr unsafe.Pointer, w *Writer) {
@@ -344,6 +457,24 @@ func (*float64Codec) Encode(ptr unsafe.Pointer, w *Writer) {
 	w.WriteDouble(*((*float64)(ptr)))
 }
 
+type float64IntCodec struct{}
+
+func (*float64IntCodec) Decode(ptr unsafe.Pointer, r *Reader) {
+	*((*float64)(ptr)) = float64(r.ReadInt())
+}
+
+type float64LongCodec struct{}
+
+func (*float64LongCodec) Decode(ptr unsafe.Pointer, r *Reader) {
+	*((*float64)(ptr)) = float64(r.ReadLong())
+}
+
+type float64FloatCodec struct{}
+
+func (*float64FloatCodec) Decode(ptr unsafe.Pointer, r *Reader) {
+	*((*float64)(ptr)) = float64(r.ReadFloat())
+}
+
 type stringCodec struct{}
 
 func (*stringCodec) Decode(ptr unsafe.Pointer, r *Reader) {
@@ -367,6 +498,36 @@ func (c *bytesCodec) Encode(ptr unsafe.Pointer, w *Writer) {
 	w.WriteBytes(*((*[]byte)(ptr)))
 }
 
+type uuidStringCodec struct{}
+
+func (c *uuidStringCodec) Decode(ptr unsafe.Pointer, r *Reader) {
+	*((*string)(ptr)) = r.ReadString()
+}
+
+func (c *uuidStringCodec) Encode(ptr unsafe.Pointer, w *Writer) {
+	s := *((*string)(ptr))
+	if _, err := parseUUID(s); err != nil {
+		w.Error = err
+		return
+	}
+	w.WriteString(s)
+}
+
+type uuidCodec struct{}
+
+func (c *uuidCodec) Decode(ptr unsafe.Pointer, r *Reader) {
+	u, err := parseUUID(r.ReadString())
+	if err != nil {
+		r.ReportError("uuidCodec", err.Error())
+		return
+	}
+	*((*[16]byte)(ptr)) = u
+}
+
+func (c *uuidCodec) Encode(ptr unsafe.Pointer, w *Writer) {
+	w.WriteString(formatUUID(*((*[16]byte)(ptr))))
+}
+
 type dateCodec struct{}
 
 func (c *dateCodec) Decode(ptr unsafe.Pointer, r *Reader) {
@@ -379,7 +540,9 @@ func (c *dateCodec) Encode(ptr unsafe.Pointer, w *Writer) {
 	w.WriteInt(int32(t.UnixNano() / int64(24*time.Hour)))
 }
 
-type timestampMillisCodec struct{}
+type timestampMillisCodec struct {
+	local bool
+}
 
 func (c *timestampMillisCodec) Decode(ptr unsafe.Pointer, r *Reader) {
 	i := r.ReadLong()
@@ -390,10 +553,15 @@ func (c *timestampMillisCodec) Decode(ptr unsafe.Pointer, r *Reader) {
 
 func (c *timestampMillisCodec) Encode(ptr unsafe.Pointer, w *Writer) {
 	t := *((*time.Time)(ptr))
+	if c.local {
+		t = wallClock(t)
+	}
 	w.WriteLong(t.Unix()*1e3 + int64(t.Nanosecond()/1e6))
 }
 
-type timestampMicrosCodec struct{}
+type timestampMicrosCodec struct {
+	local bool
+}
 
 func (c *timestampMicrosCodec) Decode(ptr unsafe.Pointer, r *Reader) {
 	i := r.ReadLong()
@@ -404,9 +572,36 @@ func (c *timestampMicrosCodec) Decode(ptr unsafe.Pointer, r *Reader) {
 
 func (c *timestampMicrosCodec) Encode(ptr unsafe.Pointer, w *Writer) {
 	t := *((*time.Time)(ptr))
+	if c.local {
+		t = wallClock(t)
+	}
 	w.WriteLong(t.Unix()*1e6 + int64(t.Nanosecond()/1e3))
 }
 
+type timestampNanosCodec struct {
+	local bool
+}
+
+func (c *timestampNanosCodec) Decode(ptr unsafe.Pointer, r *Reader) {
+	*((*time.Time)(ptr)) = time.Unix(0, r.ReadLong()).UTC()
+}
+
+func (c *timestampNanosCodec) Encode(ptr unsafe.Pointer, w *Writer) {
+	t := *((*time.Time)(ptr))
+	if c.local {
+		t = wallClock(t)
+	}
+	w.WriteLong(t.Unix()*1e9 + int64(t.Nanosecond()))
+}
+
+// wallClock returns the time in UTC with the same wall-clock time as t.
+//
+// Local timestamps have no time zone, they are encoded from the wall-clock time
+// in the location of the time, and decoded as a time in UTC.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
 type timeMillisCodec struct{}
 
 func (c *timeMillisCodec) Decode(ptr unsafe.Pointer, r *Reader) {
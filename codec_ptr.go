@@ -57,6 +57,14 @@ func (d *dereferenceEncoder) Encode(ptr unsafe.Pointer, w *Writer) {
 	d.encoder.Encode(*((*unsafe.Pointer)(ptr)), w)
 }
 
+type referenceEncoder struct {
+	encoder ValEncoder
+}
+
+func (encoder *referenceEncoder) Encode(ptr unsafe.Pointer, w *Writer) {
+	encoder.encoder.Encode(unsafe.Pointer(&ptr), w)
+}
+
 type referenceDecoder struct {
 	decoder ValDecoder
 }
@@ -60,8 +60,24 @@ func decoderOfStruct(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValDec
 	for _, field := range rec.Fields() {
 		sf := structDesc.Fields.Get(field.Name())
 
+		if field.action == fieldActionSetDefault {
+			// The field is not encoded, there is nothing to skip
+			if sf == nil || field.encodedDef == nil {
+				continue
+			}
+
+			fields = append(fields, &structFieldDecoder{
+				field: sf.Field,
+				decoder: &defaultDecoder{
+					data:    field.encodedDef,
+					decoder: decoderOfType(cfg, field.Type(), sf.Field.Type()),
+				},
+			})
+			continue
+		}
+
 		// Skip field if it doesnt exist
-		if sf == nil {
+		if sf == nil || field.action == fieldActionDrain {
 			fields = append(fields, &structFieldDecoder{
 				decoder: createSkipDecoder(field.Type()),
 			})
@@ -194,11 +210,35 @@ func decoderOfRecord(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValDec
 	rec := schema.(*RecordSchema)
 	mapType := typ.(*reflect2.UnsafeMapType)
 
-	fields := make([]recordMapDecoderField, len(rec.Fields()))
-	for i, field := range rec.Fields() {
-		fields[i] = recordMapDecoderField{
-			name:    field.Name(),
-			decoder: decoderOfType(cfg, field.Type(), mapType.Elem()),
+	fields := make([]recordMapDecoderField, 0, len(rec.Fields()))
+	for _, field := range rec.Fields() {
+		switch field.action {
+		case fieldActionDrain:
+			fields = append(fields, recordMapDecoderField{
+				name:    field.Name(),
+				skip:    true,
+				decoder: createSkipDecoder(field.Type()),
+			})
+
+		case fieldActionSetDefault:
+			// The field is not encoded, there is nothing to skip
+			if field.encodedDef == nil {
+				continue
+			}
+
+			fields = append(fields, recordMapDecoderField{
+				name: field.Name(),
+				decoder: &defaultDecoder{
+					data:    field.encodedDef,
+					decoder: decoderOfType(cfg, field.Type(), mapType.Elem()),
+				},
+			})
+
+		default:
+			fields = append(fields, recordMapDecoderField{
+				name:    field.Name(),
+				decoder: decoderOfType(cfg, field.Type(), mapType.Elem()),
+			})
 		}
 	}
 
@@ -211,6 +251,7 @@ func decoderOfRecord(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValDec
 
 type recordMapDecoderField struct {
 	name    string
+	skip    bool
 	decoder ValDecoder
 }
 
@@ -226,6 +267,11 @@ func (d *recordMapDecoder) Decode(ptr unsafe.Pointer, r *Reader) {
 	}
 
 	for _, field := range d.fields {
+		if field.skip {
+			field.decoder.Decode(nil, r)
+			continue
+		}
+
 		elem := d.elemType.UnsafeNew()
 		field.decoder.Decode(elem, r)
 
@@ -6,7 +6,7 @@ import (
 )
 
 func createSkipDecoder(schema Schema) ValDecoder {
-	switch schema.Type() {
+	switch getEncodedType(schema) {
 	case Boolean:
 		return &boolSkipDecoder{}
 
@@ -99,9 +99,14 @@ func (c *bytesSkipDecoder) Decode(_ unsafe.Pointer, r *Reader) {
 func skipDecoderOfRecord(schema Schema) ValDecoder {
 	rec := schema.(*RecordSchema)
 
-	decoders := make([]ValDecoder, len(rec.Fields()))
-	for i, field := range rec.Fields() {
-		decoders[i] = createSkipDecoder(field.Type())
+	decoders := make([]ValDecoder, 0, len(rec.Fields()))
+	for _, field := range rec.Fields() {
+		// Defaulted fields of a resolved record are not encoded
+		if field.action == fieldActionSetDefault {
+			continue
+		}
+
+		decoders = append(decoders, createSkipDecoder(field.Type()))
 	}
 
 	return &recordSkipDecoder{
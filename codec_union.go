@@ -11,6 +11,10 @@ import (
 )
 
 func createDecoderOfUnion(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValDecoder {
+	if schema.(*UnionSchema).flat {
+		return decoderOfFlatUnion(cfg, schema, typ)
+	}
+
 	switch typ.Kind() {
 	case reflect.Map:
 		if typ.(reflect2.MapType).Key().Kind() != reflect.String ||
@@ -302,6 +306,34 @@ func (d *unionResolvedDecoder) Decode(ptr unsafe.Pointer, r *Reader) {
 	*pObj = typ.UnsafeIndirect(newPtr)
 }
 
+func decoderOfFlatUnion(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValDecoder {
+	union := schema.(*UnionSchema)
+
+	decoders := make([]ValDecoder, len(union.Types()))
+	for i, schema := range union.Types() {
+		decoders[i] = decoderOfType(cfg, schema, typ)
+	}
+
+	return &unionFlatDecoder{
+		schema:   union,
+		decoders: decoders,
+	}
+}
+
+type unionFlatDecoder struct {
+	schema   *UnionSchema
+	decoders []ValDecoder
+}
+
+func (d *unionFlatDecoder) Decode(ptr unsafe.Pointer, r *Reader) {
+	i, schema := getUnionSchema(d.schema, r)
+	if schema == nil {
+		return
+	}
+
+	d.decoders[i].Decode(ptr, r)
+}
+
 func unionResolutionName(schema Schema) string {
 	name := schemaTypeName(schema)
 	switch schema.Type() {
@@ -362,6 +394,11 @@ func (e *unionResolverEncoder) Encode(ptr unsafe.Pointer, w *Writer) {
 func getUnionSchema(schema *UnionSchema, r *Reader) (int, Schema) {
 	types := schema.Types()
 
+	// The writer schema was not a union, there is no index to read.
+	if schema.fixed {
+		return schema.fixedIdx, types[schema.fixedIdx]
+	}
+
 	idx := int(r.ReadLong())
 	if idx < 0 || idx > len(types)-1 {
 		r.ReportError("decode union type", "unknown union type")
@@ -1,6 +1,8 @@
 package avro
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
 	"sync"
 
@@ -24,6 +26,10 @@ type Config struct {
 	// UnionResolutionError determines if an error will be returned
 	// when a type cannot be resolved while decoding a union.
 	UnionResolutionError bool
+
+	// LogicalTypes are the codecs of custom logical types, by logical type.
+	// Go types a codec does not support are en/decoded as for the annotated type.
+	LogicalTypes map[LogicalType]LogicalTypeCodec
 }
 
 // Freeze makes the configuration immutable.
@@ -33,6 +39,7 @@ func (c Config) Freeze() API {
 		decoderCache: concurrent.NewMap(),
 		encoderCache: concurrent.NewMap(),
 		resolver:     NewTypeResolver(),
+		compat:       NewSchemaCompatibility(),
 	}
 
 	api.readerPool = &sync.Pool{
@@ -75,6 +82,28 @@ type API interface {
 	// NewDecoder returns a new decoder that reads from reader r using schema.
 	NewDecoder(schema Schema, r io.Reader) *Decoder
 
+	// UnmarshalResolved parses the Avro encoded data written with the writer schema and stores
+	// the result, resolved into the reader schema, in the value pointed to by v.
+	// If the schemas are not compatible, or v is nil or not a pointer, UnmarshalResolved returns an error.
+	UnmarshalResolved(reader, writer Schema, data []byte, v interface{}) error
+
+	// NewDecoderWithReaderSchema returns a new decoder that reads data written with the writer schema
+	// from r, resolving it into the reader schema.
+	NewDecoderWithReaderSchema(reader, writer Schema, r io.Reader) (*Decoder, error)
+
+	// MarshalToJSON returns the Avro JSON encoding of v.
+	MarshalToJSON(schema Schema, v interface{}) ([]byte, error)
+
+	// UnmarshalFromJSON parses the Avro JSON encoded data and stores the result in the value pointed to by v.
+	// If v is nil or not a pointer, UnmarshalFromJSON returns an error.
+	UnmarshalFromJSON(schema Schema, data []byte, v interface{}) error
+
+	// NewJSONEncoder returns a new JSON encoder that writes to w using schema.
+	NewJSONEncoder(schema Schema, w io.Writer) *JSONEncoder
+
+	// NewJSONDecoder returns a new JSON decoder that reads from r using schema.
+	NewJSONDecoder(schema Schema, r io.Reader) *JSONDecoder
+
 	// DecoderOf returns the value decoder for a given schema and type.
 	DecoderOf(schema Schema, typ reflect2.Type) ValDecoder
 
@@ -95,6 +124,7 @@ type frozenConfig struct {
 	writerPool *sync.Pool
 
 	resolver *TypeResolver
+	compat   *SchemaCompatibility
 }
 
 func (c *frozenConfig) Marshal(schema Schema, v interface{}) ([]byte, error) {
@@ -169,6 +199,89 @@ func (c *frozenConfig) NewDecoder(schema Schema, r io.Reader) *Decoder {
 	}
 }
 
+func (c *frozenConfig) UnmarshalResolved(reader, writer Schema, data []byte, v interface{}) error {
+	schema, err := c.compat.Resolve(reader, writer)
+	if err != nil {
+		return err
+	}
+
+	return c.Unmarshal(schema, data, v)
+}
+
+func (c *frozenConfig) NewDecoderWithReaderSchema(reader, writer Schema, r io.Reader) (*Decoder, error) {
+	schema, err := c.compat.Resolve(reader, writer)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.NewDecoder(schema, r), nil
+}
+
+func (c *frozenConfig) MarshalToJSON(schema Schema, v interface{}) ([]byte, error) {
+	data, err := c.Marshal(schema, v)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := c.borrowReader(data)
+
+	b := appendJSON(nil, reader, schema)
+	err = reader.Error
+	c.returnReader(reader)
+
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (c *frozenConfig) UnmarshalFromJSON(schema Schema, data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var val interface{}
+	if err := dec.Decode(&val); err != nil {
+		return err
+	}
+
+	return c.unmarshalJSONValue(schema, val, v)
+}
+
+func (c *frozenConfig) unmarshalJSONValue(schema Schema, val, v interface{}) error {
+	writer := c.borrowWriter()
+
+	writeJSON(writer, schema, val)
+	if err := writer.Error; err != nil {
+		c.returnWriter(writer)
+		return err
+	}
+
+	err := c.Unmarshal(schema, writer.Buffer(), v)
+	c.returnWriter(writer)
+
+	return err
+}
+
+func (c *frozenConfig) NewJSONEncoder(schema Schema, w io.Writer) *JSONEncoder {
+	return &JSONEncoder{
+		cfg: c,
+		s:   schema,
+		w:   w,
+	}
+}
+
+func (c *frozenConfig) NewJSONDecoder(schema Schema, r io.Reader) *JSONDecoder {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	return &JSONDecoder{
+		cfg: c,
+		s:   schema,
+		d:   dec,
+	}
+}
+
 func (c *frozenConfig) Register(name string, obj interface{}) {
 	c.resolver.Register(name, obj)
 }
@@ -25,6 +25,12 @@ func NewDecoderForSchema(schema Schema, reader io.Reader) *Decoder {
 	return DefaultConfig.NewDecoder(schema, reader)
 }
 
+// NewDecoderWithReaderSchema returns a new decoder that reads data written with the writer
+// schema from r, resolving it into the reader schema.
+func NewDecoderWithReaderSchema(reader, writer Schema, r io.Reader) (*Decoder, error) {
+	return DefaultConfig.NewDecoderWithReaderSchema(reader, writer, r)
+}
+
 // Decode reads the next Avro encoded value from its input and stores it in the value pointed to by v.
 func (d *Decoder) Decode(obj interface{}) error {
 	if d.r.head == d.r.tail && d.r.reader != nil {
@@ -47,3 +53,10 @@ func (d *Decoder) Decode(obj interface{}) error {
 func Unmarshal(schema Schema, data []byte, v interface{}) error {
 	return DefaultConfig.Unmarshal(schema, data, v)
 }
+
+// UnmarshalResolved parses the Avro encoded data written with the writer schema and stores
+// the result, resolved into the reader schema, in the value pointed to by v.
+// If the schemas are not compatible, or v is nil or not a pointer, UnmarshalResolved returns an error.
+func UnmarshalResolved(reader, writer Schema, data []byte, v interface{}) error {
+	return DefaultConfig.UnmarshalResolved(reader, writer, data, v)
+}
@@ -95,3 +95,76 @@ func TestDecoder_FixedRatInvalidLogicalSchema(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestDecoder_FixedLogicalDuration(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x0C, 0x00, 0x00, 0x00, 0x22, 0x00, 0x00, 0x00, 0x52, 0xAA, 0x08, 0x00}
+	schema := `{"type":"fixed","name":"test","size":12,"logicalType":"duration"}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got avro.LogicalDuration
+	err = dec.Decode(&got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, avro.LogicalDuration{Months: 12, Days: 34, Milliseconds: 567890}, got)
+}
+
+func TestDecoder_FixedLogicalDurationInvalidLogicalSchema(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x0C, 0x00, 0x00, 0x00, 0x22, 0x00, 0x00, 0x00, 0x52, 0xAA, 0x08, 0x00}
+	schema := `{"type":"fixed","name":"test","size":12}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got avro.LogicalDuration
+	err = dec.Decode(&got)
+
+	assert.Error(t, err)
+}
+
+func TestDecoder_FixedLogicalUUID(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0xF8, 0x1D, 0x4F, 0xAE, 0x7D, 0xEC, 0x11, 0xD0, 0xA7, 0x65, 0x00, 0xA0, 0xC9, 0x1E, 0x6B, 0xF6}
+	schema := `{"type":"fixed","name":"test","size":16,"logicalType":"uuid"}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got [16]byte
+	err = dec.Decode(&got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, [16]byte{0xF8, 0x1D, 0x4F, 0xAE, 0x7D, 0xEC, 0x11, 0xD0, 0xA7, 0x65, 0x00, 0xA0, 0xC9, 0x1E, 0x6B, 0xF6}, got)
+}
+
+func TestDecoder_FixedLogicalUUIDString(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0xF8, 0x1D, 0x4F, 0xAE, 0x7D, 0xEC, 0x11, 0xD0, 0xA7, 0x65, 0x00, 0xA0, 0xC9, 0x1E, 0x6B, 0xF6}
+	schema := `{"type":"fixed","name":"test","size":16,"logicalType":"uuid"}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got string
+	err = dec.Decode(&got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", got)
+}
+
+func TestDecoder_FixedLogicalUUIDStringInvalidLogicalSchema(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0xF8, 0x1D, 0x4F, 0xAE, 0x7D, 0xEC, 0x11, 0xD0, 0xA7, 0x65, 0x00, 0xA0, 0xC9, 0x1E, 0x6B, 0xF6}
+	schema := `{"type":"fixed","name":"test","size":16}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got string
+	err = dec.Decode(&got)
+
+	assert.Error(t, err)
+}
@@ -285,6 +285,66 @@ func TestDecoder_StringInvalidSchema(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDecoder_StringLogicalUUID(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x48, 0x66, 0x38, 0x31, 0x64, 0x34, 0x66, 0x61, 0x65, 0x2D, 0x37, 0x64, 0x65, 0x63, 0x2D, 0x31, 0x31, 0x64, 0x30, 0x2D, 0x61, 0x37, 0x36, 0x35, 0x2D, 0x30, 0x30, 0x61, 0x30, 0x63, 0x39, 0x31, 0x65, 0x36, 0x62, 0x66, 0x36}
+	schema := `{"type":"string","logicalType":"uuid"}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got [16]byte
+	err = dec.Decode(&got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, [16]byte{0xF8, 0x1D, 0x4F, 0xAE, 0x7D, 0xEC, 0x11, 0xD0, 0xA7, 0x65, 0x00, 0xA0, 0xC9, 0x1E, 0x6B, 0xF6}, got)
+}
+
+func TestDecoder_StringLogicalUUIDNamedType(t *testing.T) {
+	defer ConfigTeardown()
+
+	type UUID [16]byte
+
+	data := []byte{0x48, 0x66, 0x38, 0x31, 0x64, 0x34, 0x66, 0x61, 0x65, 0x2D, 0x37, 0x64, 0x65, 0x63, 0x2D, 0x31, 0x31, 0x64, 0x30, 0x2D, 0x61, 0x37, 0x36, 0x35, 0x2D, 0x30, 0x30, 0x61, 0x30, 0x63, 0x39, 0x31, 0x65, 0x36, 0x62, 0x66, 0x36}
+	schema := `{"type":"string","logicalType":"uuid"}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got UUID
+	err = dec.Decode(&got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, UUID([16]byte{0xF8, 0x1D, 0x4F, 0xAE, 0x7D, 0xEC, 0x11, 0xD0, 0xA7, 0x65, 0x00, 0xA0, 0xC9, 0x1E, 0x6B, 0xF6}), got)
+}
+
+func TestDecoder_StringLogicalUUIDInvalidString(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x06, 0x66, 0x6F, 0x6F}
+	schema := `{"type":"string","logicalType":"uuid"}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got [16]byte
+	err = dec.Decode(&got)
+
+	assert.Error(t, err)
+}
+
+func TestDecoder_StringLogicalUUIDInvalidLogicalSchema(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x48, 0x66, 0x38, 0x31, 0x64, 0x34, 0x66, 0x61, 0x65, 0x2D, 0x37, 0x64, 0x65, 0x63, 0x2D, 0x31, 0x31, 0x64, 0x30, 0x2D, 0x61, 0x37, 0x36, 0x35, 0x2D, 0x30, 0x30, 0x61, 0x30, 0x63, 0x39, 0x31, 0x65, 0x36, 0x62, 0x66, 0x36}
+	schema := "string"
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got [16]byte
+	err = dec.Decode(&got)
+
+	assert.Error(t, err)
+}
+
 func TestDecoder_Bytes(t *testing.T) {
 	defer ConfigTeardown()
 
@@ -419,6 +479,66 @@ func TestDecoder_Time_TimestampMillisOneMicros(t *testing.T) {
 	assert.Equal(t, time.Date(1970, 1, 1, 0, 0, 0, 1e3, time.UTC), got)
 }
 
+func TestDecoder_Time_TimestampNanos(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x8C, 0xC8, 0xB1, 0x82, 0xBD, 0xB5, 0xF9, 0xE5, 0x2B}
+	schema := `{"type":"long","logicalType":"timestamp-nanos"}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got time.Time
+	err = dec.Decode(&got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC), got)
+}
+
+func TestDecoder_Time_LocalTimestampMillis(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x90, 0xB2, 0xAE, 0xC3, 0xEC, 0x5B}
+	schema := `{"type":"long","logicalType":"local-timestamp-millis"}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got time.Time
+	err = dec.Decode(&got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), got)
+}
+
+func TestDecoder_Time_LocalTimestampMicros(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x80, 0xCD, 0xB7, 0xA2, 0xEE, 0xC7, 0xCD, 0x05}
+	schema := `{"type":"long","logicalType":"local-timestamp-micros"}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got time.Time
+	err = dec.Decode(&got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), got)
+}
+
+func TestDecoder_Time_LocalTimestampNanos(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x8C, 0xC8, 0xB1, 0x82, 0xBD, 0xB5, 0xF9, 0xE5, 0x2B}
+	schema := `{"type":"long","logicalType":"local-timestamp-nanos"}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got time.Time
+	err = dec.Decode(&got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC), got)
+}
+
 func TestDecoder_TimeInvalidSchema(t *testing.T) {
 	defer ConfigTeardown()
 
@@ -0,0 +1,967 @@
+package avro_test
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/hamba/avro"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDecoderWithReaderSchema(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x06, 0x66, 0x6f, 0x6f, 0x36}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+	    {"name": "b", "type": "string"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+	    {"name": "b", "type": "string"},
+		{"name": "a", "type": "long"}
+	]
+}`)
+	dec, err := avro.NewDecoderWithReaderSchema(reader, writer, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	var got TestRecord
+	err = dec.Decode(&got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestRecord{A: 27, B: "foo"}, got)
+}
+
+func TestNewDecoderWithReaderSchema_IncompatibleSchemas(t *testing.T) {
+	defer ConfigTeardown()
+
+	reader := avro.MustParse(`"string"`)
+	writer := avro.MustParse(`"int"`)
+
+	_, err := avro.NewDecoderWithReaderSchema(reader, writer, bytes.NewReader([]byte{}))
+
+	assert.Error(t, err)
+}
+
+func TestUnmarshalResolved(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x06, 0x66, 0x6f, 0x6f, 0x36}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+	    {"name": "b", "type": "string"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+	    {"name": "b", "type": "string"},
+		{"name": "a", "type": "long"}
+	]
+}`)
+
+	var got TestRecord
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestRecord{A: 27, B: "foo"}, got)
+}
+
+func TestUnmarshalResolved_IncompatibleSchemas(t *testing.T) {
+	defer ConfigTeardown()
+
+	reader := avro.MustParse(`"string"`)
+	writer := avro.MustParse(`"int"`)
+
+	var got string
+	err := avro.UnmarshalResolved(reader, writer, []byte{0x02}, &got)
+
+	assert.Error(t, err)
+}
+
+func TestDecoder_ResolvedRecordMap(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x06, 0x66, 0x6f, 0x6f, 0x36}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+	    {"name": "b", "type": "string"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+	    {"name": "b", "type": "string"},
+		{"name": "a", "type": "long"}
+	]
+}`)
+
+	var got map[string]interface{}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": int64(27), "b": "foo"}, got)
+}
+
+func TestDecoder_ResolvedRecordNested(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x06, 0x66, 0x6f, 0x6f, 0x36, 0x06, 0x62, 0x61, 0x72, 0x38}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "parent",
+	"fields" : [
+		{"name": "a", "type": {
+			"type": "record",
+			"name": "test",
+			"fields" : [
+				{"name": "a", "type": "long"},
+				{"name": "b", "type": "string"}
+			]
+		}},
+		{"name": "b", "type": "test"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "parent",
+	"fields" : [
+		{"name": "a", "type": {
+			"type": "record",
+			"name": "test",
+			"fields" : [
+				{"name": "b", "type": "string"},
+				{"name": "a", "type": "long"}
+			]
+		}},
+		{"name": "b", "type": "test"}
+	]
+}`)
+
+	var got TestNestedRecord
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestNestedRecord{A: TestRecord{A: 27, B: "foo"}, B: TestRecord{A: 28, B: "bar"}}, got)
+}
+
+func TestDecoder_ResolvedRecordInterface(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x06, 0x66, 0x6f, 0x6f, 0x36}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+	    {"name": "b", "type": "string"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+	    {"name": "b", "type": "string"},
+		{"name": "a", "type": "long"}
+	]
+}`)
+
+	var got interface{}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": int64(27), "b": "foo"}, got)
+}
+
+func TestDecoder_ResolvedArray(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x03, 0x14, 0x06, 0x66, 0x6f, 0x6f, 0x36, 0x06, 0x62, 0x61, 0x72, 0x38, 0x00}
+	reader := avro.MustParse(`{"type": "array", "items": {
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+	    {"name": "b", "type": "string"}
+	]
+}}`)
+	writer := avro.MustParse(`{"type": "array", "items": {
+	"type": "record",
+	"name": "test",
+	"fields" : [
+	    {"name": "b", "type": "string"},
+		{"name": "a", "type": "long"}
+	]
+}}`)
+
+	var got []TestRecord
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []TestRecord{{A: 27, B: "foo"}, {A: 28, B: "bar"}}, got)
+}
+
+func TestDecoder_ResolvedMap(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x01, 0x0c, 0x06, 0x66, 0x6f, 0x6f, 0x06, 0x66, 0x6f, 0x6f, 0x36, 0x00}
+	reader := avro.MustParse(`{"type": "map", "values": {
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+	    {"name": "b", "type": "string"}
+	]
+}}`)
+	writer := avro.MustParse(`{"type": "map", "values": {
+	"type": "record",
+	"name": "test",
+	"fields" : [
+	    {"name": "b", "type": "string"},
+		{"name": "a", "type": "long"}
+	]
+}}`)
+
+	var got map[string]TestRecord
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]TestRecord{"foo": {A: 27, B: "foo"}}, got)
+}
+
+func TestDecoder_ResolvedEnum(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x02}
+	reader := avro.MustParse(`{"type":"enum", "name": "test", "symbols": ["baz", "bar", "foo"]}`)
+	writer := avro.MustParse(`{"type":"enum", "name": "test", "symbols": ["foo", "bar"]}`)
+
+	var got string
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", got)
+}
+
+func TestDecoder_ResolvedEnumDefault(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x04}
+	reader := avro.MustParse(`{"type":"enum", "name": "test", "symbols": ["foo", "bar", "unknown"], "default": "unknown"}`)
+	writer := avro.MustParse(`{"type":"enum", "name": "test", "symbols": ["foo", "bar", "baz"]}`)
+
+	var got string
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "unknown", got)
+}
+
+func TestDecoder_ResolvedEnumDefaultInterface(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x04}
+	reader := avro.MustParse(`{"type":"enum", "name": "test", "symbols": ["foo", "bar", "unknown"], "default": "unknown"}`)
+	writer := avro.MustParse(`{"type":"enum", "name": "test", "symbols": ["foo", "bar", "baz"]}`)
+
+	var got interface{}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "unknown", got)
+}
+
+func TestDecoder_ResolvedUnionPtr(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x00, 0x06, 0x66, 0x6f, 0x6f}
+	reader := avro.MustParse(`["null", "string"]`)
+	writer := avro.MustParse(`["string", "null"]`)
+
+	var got *string
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	want := "foo"
+	assert.NoError(t, err)
+	assert.Equal(t, &want, got)
+}
+
+func TestDecoder_ResolvedUnionPtrNull(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x02}
+	reader := avro.MustParse(`["null", "string"]`)
+	writer := avro.MustParse(`["string", "null"]`)
+
+	got := new(string)
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestDecoder_ResolvedUnionMap(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x00, 0x06, 0x66, 0x6f, 0x6f}
+	reader := avro.MustParse(`["null", "int", "string"]`)
+	writer := avro.MustParse(`["string", "null"]`)
+
+	var got map[string]interface{}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"string": "foo"}, got)
+}
+
+func TestDecoder_ResolvedUnionInterface(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x00, 0x06, 0x66, 0x6f, 0x6f}
+	reader := avro.MustParse(`["null", "int", "string"]`)
+	writer := avro.MustParse(`["string", "null"]`)
+
+	var got interface{}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", got)
+}
+
+func TestDecoder_ResolvedWriterUnion(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x00, 0x06, 0x66, 0x6f, 0x6f}
+	reader := avro.MustParse(`"string"`)
+	writer := avro.MustParse(`["string"]`)
+
+	var got string
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", got)
+}
+
+func TestDecoder_ResolvedWriterUnionInterface(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x00, 0x06, 0x66, 0x6f, 0x6f}
+	reader := avro.MustParse(`"string"`)
+	writer := avro.MustParse(`["string"]`)
+
+	var got interface{}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", got)
+}
+
+func TestDecoder_ResolvedReaderUnionPtr(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x06, 0x66, 0x6f, 0x6f}
+	reader := avro.MustParse(`["null", "string"]`)
+	writer := avro.MustParse(`"string"`)
+
+	var got *string
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	want := "foo"
+	assert.NoError(t, err)
+	assert.Equal(t, &want, got)
+}
+
+func TestDecoder_ResolvedReaderUnionMap(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x06, 0x66, 0x6f, 0x6f}
+	reader := avro.MustParse(`["null", "string"]`)
+	writer := avro.MustParse(`"string"`)
+
+	var got map[string]interface{}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"string": "foo"}, got)
+}
+
+func TestDecoder_ResolvedReaderUnionInterface(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x36}
+	reader := avro.MustParse(`["null", "string", "long"]`)
+	writer := avro.MustParse(`"long"`)
+
+	var got interface{}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(27), got)
+}
+
+func TestDecoder_ResolvedRecordDefaults(t *testing.T) {
+	defer ConfigTeardown()
+
+	type record struct {
+		A int64             `avro:"a"`
+		B string            `avro:"b"`
+		C []byte            `avro:"c"`
+		D []int             `avro:"d"`
+		E map[string]string `avro:"e"`
+		F TestRecord        `avro:"f"`
+		G *string           `avro:"g"`
+		H string            `avro:"h"`
+		I [3]byte           `avro:"i"`
+		J float64           `avro:"j"`
+	}
+
+	data := []byte{0x36}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "b", "type": "string", "default": "foo"},
+		{"name": "c", "type": "bytes", "default": "ÿbar"},
+		{"name": "d", "type": {"type": "array", "items": "int"}, "default": [1, 2]},
+		{"name": "e", "type": {"type": "map", "values": "string"}, "default": {"foo": "bar"}},
+		{"name": "f", "type": {
+			"type": "record",
+			"name": "nested",
+			"fields": [
+				{"name": "a", "type": "long"},
+				{"name": "b", "type": "string", "default": "baz"}
+			]
+		}, "default": {"a": 3}},
+		{"name": "g", "type": ["null", "string"], "default": null},
+		{"name": "h", "type": {"type": "enum", "name": "sym", "symbols": ["foo", "bar"]}, "default": "bar"},
+		{"name": "i", "type": {"type": "fixed", "name": "fix", "size": 3}, "default": "abc"},
+		{"name": "j", "type": "double", "default": 1.5}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"}
+	]
+}`)
+
+	foo := "foo"
+	got := record{G: &foo}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, record{
+		A: 27,
+		B: "foo",
+		C: []byte{0xff, 'b', 'a', 'r'},
+		D: []int{1, 2},
+		E: map[string]string{"foo": "bar"},
+		F: TestRecord{A: 3, B: "baz"},
+		G: nil,
+		H: "bar",
+		I: [3]byte{'a', 'b', 'c'},
+		J: 1.5,
+	}, got)
+}
+
+func TestDecoder_ResolvedRecordDefaultsMap(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x36}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "b", "type": "string", "default": "foo"},
+		{"name": "c", "type": ["null", "string"], "default": null}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"}
+	]
+}`)
+
+	var got map[string]interface{}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": int64(27), "b": "foo", "c": nil}, got)
+}
+
+func TestDecoder_ResolvedRecordDefaultsInterface(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x36}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "b", "type": "string", "default": "foo"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"}
+	]
+}`)
+
+	var got interface{}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": int64(27), "b": "foo"}, got)
+}
+
+func TestDecoder_ResolvedRecordDefaultsDistinguishesReaders(t *testing.T) {
+	defer ConfigTeardown()
+
+	type TestRecord struct {
+		A int64 `avro:"a"`
+		B int64 `avro:"b"`
+	}
+
+	data := []byte{0x36}
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"}
+	]
+}`)
+
+	for _, def := range []int64{1, 2} {
+		reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "b", "type": "long", "default": ` + strconv.FormatInt(def, 10) + `}
+	]
+}`)
+
+		var got TestRecord
+		err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+		assert.NoError(t, err)
+		assert.Equal(t, TestRecord{A: 27, B: def}, got)
+	}
+}
+
+func TestDecoder_ResolvedRecordSkipsWriterFields(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x36, 0x06, 0x62, 0x61, 0x72, 0x02, 0x06, 0x66, 0x6f, 0x6f}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "b", "type": "string", "default": "baz"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "c", "type": "string"},
+		{"name": "d", "type": ["null", "string"]}
+	]
+}`)
+
+	type record struct {
+		A int64  `avro:"a"`
+		B string `avro:"b"`
+		C string `avro:"c"`
+	}
+
+	var got record
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, record{A: 27, B: "baz"}, got)
+}
+
+func TestDecoder_ResolvedRecordSkipsWriterFieldsMap(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x36, 0x06, 0x62, 0x61, 0x72}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "c", "type": "string"}
+	]
+}`)
+
+	var got map[string]interface{}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": int64(27)}, got)
+}
+
+func TestDecoder_ResolvedRecordSkipsResolvedField(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x36, 0x38, 0x06, 0x66, 0x6f, 0x6f}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "c", "type": {
+			"type": "record",
+			"name": "nested",
+			"fields": [
+				{"name": "a", "type": "long"},
+				{"name": "b", "type": "string", "default": "baz"}
+			]
+		}},
+		{"name": "b", "type": "string"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "c", "type": {
+			"type": "record",
+			"name": "nested",
+			"fields": [
+				{"name": "a", "type": "long"}
+			]
+		}},
+		{"name": "b", "type": "string"}
+	]
+}`)
+
+	var got TestRecord
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestRecord{A: 27, B: "foo"}, got)
+}
+
+func TestDecoder_ResolvedPromotion(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		reader string
+		writer string
+		got    interface{}
+		want   interface{}
+	}{
+		{
+			name:   "Int To Long",
+			data:   []byte{0x36},
+			reader: `"long"`,
+			writer: `"int"`,
+			got:    new(int64),
+			want:   int64(27),
+		},
+		{
+			name:   "Int To Float",
+			data:   []byte{0x36},
+			reader: `"float"`,
+			writer: `"int"`,
+			got:    new(float32),
+			want:   float32(27),
+		},
+		{
+			name:   "Int To Double",
+			data:   []byte{0x36},
+			reader: `"double"`,
+			writer: `"int"`,
+			got:    new(float64),
+			want:   float64(27),
+		},
+		{
+			name:   "Long To Float",
+			data:   []byte{0x36},
+			reader: `"float"`,
+			writer: `"long"`,
+			got:    new(float32),
+			want:   float32(27),
+		},
+		{
+			name:   "Long To Double",
+			data:   []byte{0x36},
+			reader: `"double"`,
+			writer: `"long"`,
+			got:    new(float64),
+			want:   float64(27),
+		},
+		{
+			name:   "Float To Double",
+			data:   []byte{0x33, 0x33, 0x93, 0x3F},
+			reader: `"double"`,
+			writer: `"float"`,
+			got:    new(float64),
+			want:   float64(float32(1.15)),
+		},
+		{
+			name:   "String To Bytes",
+			data:   []byte{0x06, 0x66, 0x6f, 0x6f},
+			reader: `"bytes"`,
+			writer: `"string"`,
+			got:    new([]byte),
+			want:   []byte("foo"),
+		},
+		{
+			name:   "Bytes To String",
+			data:   []byte{0x06, 0x66, 0x6f, 0x6f},
+			reader: `"string"`,
+			writer: `"bytes"`,
+			got:    new(string),
+			want:   "foo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer ConfigTeardown()
+
+			reader := avro.MustParse(tt.reader)
+			writer := avro.MustParse(tt.writer)
+
+			err := avro.UnmarshalResolved(reader, writer, tt.data, tt.got)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, reflect.ValueOf(tt.got).Elem().Interface())
+		})
+	}
+}
+
+func TestDecoder_ResolvedPromotionInterface(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		reader string
+		writer string
+		want   interface{}
+	}{
+		{
+			name:   "Int To Long",
+			data:   []byte{0x36},
+			reader: `"long"`,
+			writer: `"int"`,
+			want:   int64(27),
+		},
+		{
+			name:   "Int To Float",
+			data:   []byte{0x36},
+			reader: `"float"`,
+			writer: `"int"`,
+			want:   float32(27),
+		},
+		{
+			name:   "Long To Double",
+			data:   []byte{0x36},
+			reader: `"double"`,
+			writer: `"long"`,
+			want:   float64(27),
+		},
+		{
+			name:   "Float To Double",
+			data:   []byte{0x33, 0x33, 0x93, 0x3F},
+			reader: `"double"`,
+			writer: `"float"`,
+			want:   float64(float32(1.15)),
+		},
+		{
+			name:   "Bytes To String",
+			data:   []byte{0x06, 0x66, 0x6f, 0x6f},
+			reader: `"string"`,
+			writer: `"bytes"`,
+			want:   "foo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer ConfigTeardown()
+
+			reader := avro.MustParse(tt.reader)
+			writer := avro.MustParse(tt.writer)
+
+			var got interface{}
+			err := avro.UnmarshalResolved(reader, writer, tt.data, &got)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecoder_ResolvedPromotionInRecord(t *testing.T) {
+	defer ConfigTeardown()
+
+	type record struct {
+		A float64  `avro:"a"`
+		B *float64 `avro:"b"`
+		C string   `avro:"c"`
+	}
+
+	data := []byte{0x36, 0x02, 0x38, 0x06, 0x66, 0x6f, 0x6f}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "double"},
+		{"name": "b", "type": ["null", "double"]},
+		{"name": "c", "type": "string"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "int"},
+		{"name": "b", "type": ["null", "long"]},
+		{"name": "c", "type": "bytes"}
+	]
+}`)
+
+	var got record
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	b := float64(28)
+	assert.NoError(t, err)
+	assert.Equal(t, record{A: 27, B: &b, C: "foo"}, got)
+}
+
+func TestDecoder_ResolvedPromotionSkipsEncodedType(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x36, 0x38, 0x06, 0x66, 0x6f, 0x6f}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "c", "type": "double"},
+		{"name": "b", "type": "string"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "c", "type": "int"},
+		{"name": "b", "type": "string"}
+	]
+}`)
+
+	var got TestRecord
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestRecord{A: 27, B: "foo"}, got)
+}
+
+func TestDecoder_ResolvedWriterUnionPromotion(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x02, 0x36}
+	reader := avro.MustParse(`"double"`)
+	writer := avro.MustParse(`["float", "long"]`)
+
+	var got float64
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(27), got)
+}
+
+func TestDecoder_ResolvedAliases(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x06, 0x66, 0x6f, 0x6f, 0x36}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "renamed",
+	"aliases": ["test"],
+	"fields" : [
+		{"name": "a", "type": "long"},
+	    {"name": "b", "aliases": ["c"], "type": "string"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+	    {"name": "c", "type": "string"},
+		{"name": "a", "type": "long"}
+	]
+}`)
+
+	var got TestRecord
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestRecord{A: 27, B: "foo"}, got)
+}
+
+func TestDecoder_ResolvedAliasesMap(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x06, 0x66, 0x6f, 0x6f, 0x36}
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+	    {"name": "b", "aliases": ["c"], "type": "string"}
+	]
+}`)
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+	    {"name": "c", "type": "string"},
+		{"name": "a", "type": "long"}
+	]
+}`)
+
+	var got map[string]interface{}
+	err := avro.UnmarshalResolved(reader, writer, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": int64(27), "b": "foo"}, got)
+}
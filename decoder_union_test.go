@@ -436,6 +436,20 @@ func TestDecoder_UnionInterfaceWithDuration(t *testing.T) {
 	assert.Equal(t, 123456789*time.Millisecond, got["a"])
 }
 
+func TestDecoder_UnionInterfaceWithLogicalDuration(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x02, 0x01, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00}
+	schema := `{"type": "record", "name": "test", "fields" : [{"name": "a", "type": ["null", {"type": "fixed", "name": "dur", "size": 12, "logicalType": "duration"}]}]}`
+	dec, _ := avro.NewDecoder(schema, bytes.NewReader(data))
+
+	var got map[string]interface{}
+	err := dec.Decode(&got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"dur": avro.LogicalDuration{Months: 1, Days: 2, Milliseconds: 3}}, got["a"])
+}
+
 func TestDecoder_UnionInterfaceWithDecimal(t *testing.T) {
 	defer ConfigTeardown()
 
@@ -90,3 +90,71 @@ func TestEncoder_FixedRatInvalidLogicalSchema(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestEncoder_FixedLogicalDuration(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{"type":"fixed","name":"test","size":12,"logicalType":"duration"}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode(avro.LogicalDuration{Months: 12, Days: 34, Milliseconds: 567890})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x0C, 0x00, 0x00, 0x00, 0x22, 0x00, 0x00, 0x00, 0x52, 0xAA, 0x08, 0x00}, buf.Bytes())
+}
+
+func TestEncoder_FixedLogicalDurationInvalidLogicalSchema(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{"type":"fixed","name":"test","size":12}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode(avro.LogicalDuration{Months: 12, Days: 34, Milliseconds: 567890})
+
+	assert.Error(t, err)
+}
+
+func TestEncoder_FixedLogicalUUID(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{"type":"fixed","name":"test","size":16,"logicalType":"uuid"}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode([16]byte{0xF8, 0x1D, 0x4F, 0xAE, 0x7D, 0xEC, 0x11, 0xD0, 0xA7, 0x65, 0x00, 0xA0, 0xC9, 0x1E, 0x6B, 0xF6})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xF8, 0x1D, 0x4F, 0xAE, 0x7D, 0xEC, 0x11, 0xD0, 0xA7, 0x65, 0x00, 0xA0, 0xC9, 0x1E, 0x6B, 0xF6}, buf.Bytes())
+}
+
+func TestEncoder_FixedLogicalUUIDString(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{"type":"fixed","name":"test","size":16,"logicalType":"uuid"}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode("F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xF8, 0x1D, 0x4F, 0xAE, 0x7D, 0xEC, 0x11, 0xD0, 0xA7, 0x65, 0x00, 0xA0, 0xC9, 0x1E, 0x6B, 0xF6}, buf.Bytes())
+}
+
+func TestEncoder_FixedLogicalUUIDInvalidString(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{"type":"fixed","name":"test","size":16,"logicalType":"uuid"}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode("foo")
+
+	assert.Error(t, err)
+}
@@ -294,6 +294,72 @@ func TestEncoder_StringInvalidSchema(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestEncoder_StringLogicalUUID(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{"type":"string","logicalType":"uuid"}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x48, 0x66, 0x38, 0x31, 0x64, 0x34, 0x66, 0x61, 0x65, 0x2D, 0x37, 0x64, 0x65, 0x63, 0x2D, 0x31, 0x31, 0x64, 0x30, 0x2D, 0x61, 0x37, 0x36, 0x35, 0x2D, 0x30, 0x30, 0x61, 0x30, 0x63, 0x39, 0x31, 0x65, 0x36, 0x62, 0x66, 0x36}, buf.Bytes())
+}
+
+func TestEncoder_StringLogicalUUIDInvalidString(t *testing.T) {
+	defer ConfigTeardown()
+
+	tests := []string{
+		"foo",
+		"f81d4fae7dec11d0a76500a0c91e6bf6",
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bfg",
+		"f81d4fae_7dec_11d0_a765_00a0c91e6bf6",
+		"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}",
+	}
+
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			schema := `{"type":"string","logicalType":"uuid"}`
+			buf := bytes.NewBuffer([]byte{})
+			enc, err := avro.NewEncoder(schema, buf)
+			assert.NoError(t, err)
+
+			err = enc.Encode(test)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEncoder_StringLogicalUUIDArray(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{"type":"string","logicalType":"uuid"}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode([16]byte{0xF8, 0x1D, 0x4F, 0xAE, 0x7D, 0xEC, 0x11, 0xD0, 0xA7, 0x65, 0x00, 0xA0, 0xC9, 0x1E, 0x6B, 0xF6})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x48, 0x66, 0x38, 0x31, 0x64, 0x34, 0x66, 0x61, 0x65, 0x2D, 0x37, 0x64, 0x65, 0x63, 0x2D, 0x31, 0x31, 0x64, 0x30, 0x2D, 0x61, 0x37, 0x36, 0x35, 0x2D, 0x30, 0x30, 0x61, 0x30, 0x63, 0x39, 0x31, 0x65, 0x36, 0x62, 0x66, 0x36}, buf.Bytes())
+}
+
+func TestEncoder_StringLogicalUUIDArrayInvalidLogicalSchema(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := "string"
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode([16]byte{0xF8, 0x1D, 0x4F, 0xAE, 0x7D, 0xEC, 0x11, 0xD0, 0xA7, 0x65, 0x00, 0xA0, 0xC9, 0x1E, 0x6B, 0xF6})
+
+	assert.Error(t, err)
+}
+
 func TestEncoder_Bytes(t *testing.T) {
 	defer ConfigTeardown()
 
@@ -419,6 +485,62 @@ func TestEncoder_Time_TimestampMillisOneMicros(t *testing.T) {
 	assert.Equal(t, []byte{0x2}, buf.Bytes())
 }
 
+func TestEncoder_Time_TimestampNanos(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{"type":"long","logicalType":"timestamp-nanos"}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode(time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x8C, 0xC8, 0xB1, 0x82, 0xBD, 0xB5, 0xF9, 0xE5, 0x2B}, buf.Bytes())
+}
+
+func TestEncoder_Time_LocalTimestampMillis(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{"type":"long","logicalType":"local-timestamp-millis"}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode(time.Date(2020, 1, 2, 3, 4, 5, 6, time.FixedZone("CEST", 2*60*60)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x90, 0xB2, 0xAE, 0xC3, 0xEC, 0x5B}, buf.Bytes())
+}
+
+func TestEncoder_Time_LocalTimestampMicros(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{"type":"long","logicalType":"local-timestamp-micros"}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode(time.Date(2020, 1, 2, 3, 4, 5, 6, time.FixedZone("CEST", 2*60*60)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x80, 0xCD, 0xB7, 0xA2, 0xEE, 0xC7, 0xCD, 0x05}, buf.Bytes())
+}
+
+func TestEncoder_Time_LocalTimestampNanos(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{"type":"long","logicalType":"local-timestamp-nanos"}`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	err = enc.Encode(time.Date(2020, 1, 2, 3, 4, 5, 6, time.FixedZone("CEST", 2*60*60)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x8C, 0xC8, 0xB1, 0x82, 0xBD, 0xB5, 0xF9, 0xE5, 0x2B}, buf.Bytes())
+}
+
 func TestEncoder_TimeInvalidSchema(t *testing.T) {
 	defer ConfigTeardown()
 
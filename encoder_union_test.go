@@ -292,6 +292,21 @@ func TestEncoder_UnionInterfaceNamed(t *testing.T) {
 	assert.Equal(t, []byte{0x02, 0x02}, buf.Bytes())
 }
 
+func TestEncoder_UnionInterfaceLogicalDuration(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `["null", {"type":"fixed", "name": "test", "size": 12, "logicalType": "duration"}]`
+	buf := bytes.NewBuffer([]byte{})
+	enc, err := avro.NewEncoder(schema, buf)
+	assert.NoError(t, err)
+
+	var val interface{} = avro.LogicalDuration{Months: 1, Days: 2, Milliseconds: 3}
+	err = enc.Encode(val)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x02, 0x01, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00}, buf.Bytes())
+}
+
 func TestEncoder_UnionInterfaceUnregisteredType(t *testing.T) {
 	defer ConfigTeardown()
 
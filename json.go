@@ -0,0 +1,481 @@
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// JSONEncoder writes Avro values to an output stream using the Avro JSON encoding.
+type JSONEncoder struct {
+	cfg *frozenConfig
+	s   Schema
+	w   io.Writer
+}
+
+// NewJSONEncoder returns a new JSON encoder that writes to w using schema.
+func NewJSONEncoder(schema Schema, w io.Writer) *JSONEncoder {
+	return DefaultConfig.NewJSONEncoder(schema, w)
+}
+
+// Encode writes the Avro JSON encoding of v to the stream, followed by a newline.
+func (e *JSONEncoder) Encode(v interface{}) error {
+	b, err := e.cfg.MarshalToJSON(e.s, v)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.w.Write(append(b, '\n'))
+	return err
+}
+
+// JSONDecoder reads and decodes Avro JSON encoded values from an input stream.
+type JSONDecoder struct {
+	cfg *frozenConfig
+	s   Schema
+	d   *json.Decoder
+}
+
+// NewJSONDecoder returns a new JSON decoder that reads from r using schema.
+func NewJSONDecoder(schema Schema, r io.Reader) *JSONDecoder {
+	return DefaultConfig.NewJSONDecoder(schema, r)
+}
+
+// Decode reads the next Avro JSON encoded value from its input and stores it in the value pointed to by v.
+func (d *JSONDecoder) Decode(v interface{}) error {
+	var val interface{}
+	if err := d.d.Decode(&val); err != nil {
+		return err
+	}
+
+	return d.cfg.unmarshalJSONValue(d.s, val, v)
+}
+
+// MarshalJSON returns the Avro JSON encoding of v.
+func MarshalJSON(schema Schema, v interface{}) ([]byte, error) {
+	return DefaultConfig.MarshalToJSON(schema, v)
+}
+
+// UnmarshalJSON parses the Avro JSON encoded data and stores the result in the value pointed to by v.
+// If v is nil or not a pointer, UnmarshalJSON returns an error.
+func UnmarshalJSON(schema Schema, data []byte, v interface{}) error {
+	return DefaultConfig.UnmarshalFromJSON(schema, data, v)
+}
+
+// appendJSON reads the next Avro element in the given schema and appends
+// its JSON encoding to b.
+func appendJSON(b []byte, r *Reader, schema Schema) []byte {
+	switch schema.Type() {
+	case Null:
+		return append(b, "null"...)
+
+	case Boolean:
+		return strconv.AppendBool(b, r.ReadBool())
+
+	case Int:
+		return strconv.AppendInt(b, int64(r.ReadInt()), 10)
+
+	case Long:
+		return strconv.AppendInt(b, r.ReadLong(), 10)
+
+	case Float:
+		return appendJSONFloat(b, float64(r.ReadFloat()), 32)
+
+	case Double:
+		return appendJSONFloat(b, r.ReadDouble(), 64)
+
+	case String:
+		return appendJSONString(b, r.ReadString())
+
+	case Bytes:
+		return appendJSONString(b, latin1String(r.ReadBytes()))
+
+	case Fixed:
+		buf := make([]byte, schema.(*FixedSchema).Size())
+		r.Read(buf)
+		return appendJSONString(b, latin1String(buf))
+
+	case Enum:
+		symbols := schema.(*EnumSchema).Symbols()
+		idx := int(r.ReadInt())
+		if idx < 0 || idx >= len(symbols) {
+			r.ReportError("MarshalJSON", "unknown enum symbol")
+			return b
+		}
+		return appendJSONString(b, symbols[idx])
+
+	case Array:
+		items := schema.(*ArraySchema).Items()
+		b = append(b, '[')
+		first := true
+		r.ReadArrayCB(func(r *Reader) bool {
+			if !first {
+				b = append(b, ',')
+			}
+			first = false
+
+			b = appendJSON(b, r, items)
+			return r.Error == nil
+		})
+		return append(b, ']')
+
+	case Map:
+		values := schema.(*MapSchema).Values()
+		b = append(b, '{')
+		first := true
+		r.ReadMapCB(func(r *Reader, key string) bool {
+			if !first {
+				b = append(b, ',')
+			}
+			first = false
+
+			b = appendJSONString(b, key)
+			b = append(b, ':')
+			b = appendJSON(b, r, values)
+			return r.Error == nil
+		})
+		return append(b, '}')
+
+	case Record:
+		b = append(b, '{')
+		for i, field := range schema.(*RecordSchema).Fields() {
+			if i > 0 {
+				b = append(b, ',')
+			}
+
+			b = appendJSONString(b, field.Name())
+			b = append(b, ':')
+			b = appendJSON(b, r, field.Type())
+		}
+		return append(b, '}')
+
+	case Union:
+		_, typ := getUnionSchema(schema.(*UnionSchema), r)
+		if typ == nil {
+			return b
+		}
+		if typ.Type() == Null {
+			return append(b, "null"...)
+		}
+
+		// Non-null union values are wrapped in an object keyed by their type name.
+		b = append(b, '{')
+		b = appendJSONString(b, jsonTypeName(typ))
+		b = append(b, ':')
+		b = appendJSON(b, r, typ)
+		return append(b, '}')
+
+	case Ref:
+		return appendJSON(b, r, schema.(*RefSchema).Schema())
+
+	default:
+		r.ReportError("MarshalJSON", fmt.Sprintf("unsupported schema type %s", schema.Type()))
+		return b
+	}
+}
+
+func appendJSONFloat(b []byte, f float64, bitSize int) []byte {
+	// JSON has no representation of these values, they are written as strings.
+	switch {
+	case math.IsNaN(f):
+		return append(b, `"NaN"`...)
+
+	case math.IsInf(f, 1):
+		return append(b, `"Infinity"`...)
+
+	case math.IsInf(f, -1):
+		return append(b, `"-Infinity"`...)
+	}
+
+	return strconv.AppendFloat(b, f, 'g', -1, bitSize)
+}
+
+func appendJSONString(b []byte, s string) []byte {
+	// Marshalling a string cannot fail.
+	str, _ := json.Marshal(s)
+	return append(b, str...)
+}
+
+// writeJSON writes a decoded JSON value in the given schema.
+func writeJSON(w *Writer, schema Schema, v interface{}) {
+	switch schema.Type() {
+	case Null:
+		if v != nil {
+			w.Error = jsonTypeError(schema, v)
+		}
+
+	case Boolean:
+		b, ok := v.(bool)
+		if !ok {
+			w.Error = jsonTypeError(schema, v)
+			return
+		}
+		w.WriteBool(b)
+
+	case Int:
+		i, err := jsonInt(v, 32)
+		if err != nil {
+			w.Error = jsonTypeError(schema, v)
+			return
+		}
+		w.WriteInt(int32(i))
+
+	case Long:
+		i, err := jsonInt(v, 64)
+		if err != nil {
+			w.Error = jsonTypeError(schema, v)
+			return
+		}
+		w.WriteLong(i)
+
+	case Float:
+		f, err := jsonFloat(v, 32)
+		if err != nil {
+			w.Error = jsonTypeError(schema, v)
+			return
+		}
+		w.WriteFloat(float32(f))
+
+	case Double:
+		f, err := jsonFloat(v, 64)
+		if err != nil {
+			w.Error = jsonTypeError(schema, v)
+			return
+		}
+		w.WriteDouble(f)
+
+	case String:
+		s, ok := v.(string)
+		if !ok {
+			w.Error = jsonTypeError(schema, v)
+			return
+		}
+		w.WriteString(s)
+
+	case Bytes:
+		s, ok := v.(string)
+		if !ok {
+			w.Error = jsonTypeError(schema, v)
+			return
+		}
+		b, ok := latin1Bytes(s)
+		if !ok {
+			w.Error = fmt.Errorf("avro: invalid ISO-8859-1 string %q for %s", s, schema.Type())
+			return
+		}
+		w.WriteBytes(b)
+
+	case Fixed:
+		s, ok := v.(string)
+		if !ok {
+			w.Error = jsonTypeError(schema, v)
+			return
+		}
+		b, ok := latin1Bytes(s)
+		if !ok {
+			w.Error = fmt.Errorf("avro: invalid ISO-8859-1 string %q for %s", s, schema.Type())
+			return
+		}
+		if len(b) != schema.(*FixedSchema).Size() {
+			w.Error = fmt.Errorf("avro: invalid fixed size %d, expected %d", len(b), schema.(*FixedSchema).Size())
+			return
+		}
+		w.Write(b)
+
+	case Enum:
+		sym, ok := v.(string)
+		if !ok {
+			w.Error = jsonTypeError(schema, v)
+			return
+		}
+		for i, s := range schema.(*EnumSchema).Symbols() {
+			if s == sym {
+				w.WriteInt(int32(i))
+				return
+			}
+		}
+		w.Error = fmt.Errorf("avro: unknown enum symbol: %s", sym)
+
+	case Array:
+		arr, ok := v.([]interface{})
+		if !ok {
+			w.Error = jsonTypeError(schema, v)
+			return
+		}
+		items := schema.(*ArraySchema).Items()
+		if len(arr) > 0 {
+			w.WriteBlockHeader(int64(len(arr)), 0)
+			for _, elem := range arr {
+				writeJSON(w, items, elem)
+				if w.Error != nil {
+					return
+				}
+			}
+		}
+		w.WriteBlockHeader(0, 0)
+
+	case Map:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			w.Error = jsonTypeError(schema, v)
+			return
+		}
+		values := schema.(*MapSchema).Values()
+		if len(m) > 0 {
+			w.WriteBlockHeader(int64(len(m)), 0)
+			for k, elem := range m {
+				w.WriteString(k)
+				writeJSON(w, values, elem)
+				if w.Error != nil {
+					return
+				}
+			}
+		}
+		w.WriteBlockHeader(0, 0)
+
+	case Record:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			w.Error = jsonTypeError(schema, v)
+			return
+		}
+		for _, field := range schema.(*RecordSchema).Fields() {
+			elem, ok := m[field.Name()]
+			if !ok {
+				if !field.HasDefault() {
+					w.Error = fmt.Errorf("avro: missing required field %s", field.Name())
+					return
+				}
+
+				def, err := encodeDefault(field)
+				if err != nil {
+					w.Error = err
+					return
+				}
+				w.Write(def)
+				continue
+			}
+
+			writeJSON(w, field.Type(), elem)
+			if w.Error != nil {
+				return
+			}
+		}
+
+	case Union:
+		writeJSONUnion(w, schema.(*UnionSchema), v)
+
+	case Ref:
+		writeJSON(w, schema.(*RefSchema).Schema(), v)
+
+	default:
+		w.Error = fmt.Errorf("avro: schema type %s is unsupported", schema.Type())
+	}
+}
+
+func writeJSONUnion(w *Writer, schema *UnionSchema, v interface{}) {
+	if v == nil {
+		for i, typ := range schema.Types() {
+			if typ.Type() == Null {
+				w.WriteLong(int64(i))
+				return
+			}
+		}
+
+		w.Error = fmt.Errorf("avro: union %s is not nullable", schema.String())
+		return
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		w.Error = fmt.Errorf("avro: union value must be an object with a single type key, got %v", v)
+		return
+	}
+
+	for name, elem := range m {
+		for i, typ := range schema.Types() {
+			if jsonTypeName(typ) != name {
+				continue
+			}
+
+			w.WriteLong(int64(i))
+			writeJSON(w, typ, elem)
+			return
+		}
+
+		w.Error = fmt.Errorf("avro: unknown union type %s", name)
+	}
+}
+
+// jsonTypeName returns the name used to identify a union type in JSON.
+func jsonTypeName(schema Schema) string {
+	if ref, ok := schema.(*RefSchema); ok {
+		schema = ref.Schema()
+	}
+
+	if n, ok := schema.(NamedSchema); ok {
+		return n.FullName()
+	}
+
+	return string(schema.Type())
+}
+
+func jsonTypeError(schema Schema, v interface{}) error {
+	return fmt.Errorf("avro: %v is unsupported for Avro %s", v, schema.Type())
+}
+
+func jsonInt(v interface{}, bitSize int) (int64, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("%v is not a number", v)
+	}
+
+	return strconv.ParseInt(string(n), 10, bitSize)
+}
+
+func jsonFloat(v interface{}, bitSize int) (float64, error) {
+	switch val := v.(type) {
+	case json.Number:
+		return strconv.ParseFloat(string(val), bitSize)
+
+	case string:
+		switch val {
+		case "NaN":
+			return math.NaN(), nil
+
+		case "Infinity":
+			return math.Inf(1), nil
+
+		case "-Infinity":
+			return math.Inf(-1), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%v is not a number", v)
+}
+
+// latin1String converts bytes to a string where each byte is an ISO-8859-1 code point.
+func latin1String(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+
+	return string(runes)
+}
+
+// latin1Bytes converts a string of ISO-8859-1 code points to bytes. It returns
+// false if the string contains a code point outside of ISO-8859-1.
+func latin1Bytes(s string) ([]byte, bool) {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xFF {
+			return nil, false
+		}
+		b = append(b, byte(r))
+	}
+
+	return b, true
+}
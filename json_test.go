@@ -0,0 +1,418 @@
+package avro_test
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/hamba/avro"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	defer ConfigTeardown()
+
+	tests := []struct {
+		name   string
+		schema string
+		value  interface{}
+		want   string
+	}{
+		{
+			name:   "Null",
+			schema: `"null"`,
+			value:  nil,
+			want:   `null`,
+		},
+		{
+			name:   "Boolean",
+			schema: `"boolean"`,
+			value:  true,
+			want:   `true`,
+		},
+		{
+			name:   "Int",
+			schema: `"int"`,
+			value:  27,
+			want:   `27`,
+		},
+		{
+			name:   "Long",
+			schema: `"long"`,
+			value:  int64(-27),
+			want:   `-27`,
+		},
+		{
+			name:   "Float",
+			schema: `"float"`,
+			value:  float32(1.15),
+			want:   `1.15`,
+		},
+		{
+			name:   "Double",
+			schema: `"double"`,
+			value:  1.15,
+			want:   `1.15`,
+		},
+		{
+			name:   "Double NaN",
+			schema: `"double"`,
+			value:  math.NaN(),
+			want:   `"NaN"`,
+		},
+		{
+			name:   "Double Infinity",
+			schema: `"double"`,
+			value:  math.Inf(-1),
+			want:   `"-Infinity"`,
+		},
+		{
+			name:   "String",
+			schema: `"string"`,
+			value:  "foo\"bar",
+			want:   `"foo\"bar"`,
+		},
+		{
+			name:   "Bytes",
+			schema: `"bytes"`,
+			value:  []byte{0x66, 0x00, 0xff},
+			want:   `"f\u0000ÿ"`,
+		},
+		{
+			name:   "Fixed",
+			schema: `{"type":"fixed", "name":"test", "size": 2}`,
+			value:  [2]byte{0x66, 0xe9},
+			want:   `"fé"`,
+		},
+		{
+			name:   "Enum",
+			schema: `{"type":"enum", "name":"test", "symbols": ["foo", "bar"]}`,
+			value:  "bar",
+			want:   `"bar"`,
+		},
+		{
+			name:   "Array",
+			schema: `{"type":"array", "items": "int"}`,
+			value:  []int{1, 2},
+			want:   `[1,2]`,
+		},
+		{
+			name:   "Map",
+			schema: `{"type":"map", "values": "int"}`,
+			value:  map[string]int{"foo": 1},
+			want:   `{"foo":1}`,
+		},
+		{
+			name:   "Record",
+			schema: `{"type":"record", "name":"test", "fields":[{"name": "a", "type": "long"}, {"name": "b", "type": "string"}]}`,
+			value:  TestRecord{A: 27, B: "foo"},
+			want:   `{"a":27,"b":"foo"}`,
+		},
+		{
+			name:   "Union Null",
+			schema: `["null", "string"]`,
+			value:  (*string)(nil),
+			want:   `null`,
+		},
+		{
+			name:   "Union Primitive",
+			schema: `["null", "string"]`,
+			value:  map[string]interface{}{"string": "foo"},
+			want:   `{"string":"foo"}`,
+		},
+		{
+			name:   "Union Named",
+			schema: `["null", {"type":"enum", "name":"test", "namespace": "org.hamba.avro", "symbols": ["foo", "bar"]}]`,
+			value:  map[string]interface{}{"org.hamba.avro.test": "foo"},
+			want:   `{"org.hamba.avro.test":"foo"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := avro.MustParse(tt.schema)
+
+			got, err := avro.MarshalJSON(schema, tt.value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestMarshalJSON_Error(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse("int")
+
+	_, err := avro.MarshalJSON(schema, true)
+
+	assert.Error(t, err)
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	defer ConfigTeardown()
+
+	tests := []struct {
+		name   string
+		schema string
+		data   string
+		got    interface{}
+		want   interface{}
+	}{
+		{
+			name:   "Boolean",
+			schema: `"boolean"`,
+			data:   `true`,
+			got:    new(bool),
+			want:   true,
+		},
+		{
+			name:   "Int",
+			schema: `"int"`,
+			data:   `27`,
+			got:    new(int),
+			want:   27,
+		},
+		{
+			name:   "Long",
+			schema: `"long"`,
+			data:   `-9007199254740993`,
+			got:    new(int64),
+			want:   int64(-9007199254740993),
+		},
+		{
+			name:   "Float",
+			schema: `"float"`,
+			data:   `1.15`,
+			got:    new(float32),
+			want:   float32(1.15),
+		},
+		{
+			name:   "Double",
+			schema: `"double"`,
+			data:   `"Infinity"`,
+			got:    new(float64),
+			want:   math.Inf(1),
+		},
+		{
+			name:   "String",
+			schema: `"string"`,
+			data:   `"foo"`,
+			got:    new(string),
+			want:   "foo",
+		},
+		{
+			name:   "Bytes",
+			schema: `"bytes"`,
+			data:   `"f\u0000ÿ"`,
+			got:    new([]byte),
+			want:   []byte{0x66, 0x00, 0xff},
+		},
+		{
+			name:   "Fixed",
+			schema: `{"type":"fixed", "name":"test", "size": 2}`,
+			data:   `"fé"`,
+			got:    new([2]byte),
+			want:   [2]byte{0x66, 0xe9},
+		},
+		{
+			name:   "Enum",
+			schema: `{"type":"enum", "name":"test", "symbols": ["foo", "bar"]}`,
+			data:   `"bar"`,
+			got:    new(string),
+			want:   "bar",
+		},
+		{
+			name:   "Array",
+			schema: `{"type":"array", "items": "int"}`,
+			data:   `[1, 2]`,
+			got:    new([]int),
+			want:   []int{1, 2},
+		},
+		{
+			name:   "Map",
+			schema: `{"type":"map", "values": "int"}`,
+			data:   `{"foo": 1}`,
+			got:    new(map[string]int),
+			want:   map[string]int{"foo": 1},
+		},
+		{
+			name:   "Record",
+			schema: `{"type":"record", "name":"test", "fields":[{"name": "a", "type": "long"}, {"name": "b", "type": "string"}]}`,
+			data:   `{"b": "foo", "a": 27}`,
+			got:    new(TestRecord),
+			want:   TestRecord{A: 27, B: "foo"},
+		},
+		{
+			name:   "Record Field Default",
+			schema: `{"type":"record", "name":"test", "fields":[{"name": "a", "type": "long", "default": 27}, {"name": "b", "type": "string"}]}`,
+			data:   `{"b": "foo"}`,
+			got:    new(TestRecord),
+			want:   TestRecord{A: 27, B: "foo"},
+		},
+		{
+			name:   "Union Null",
+			schema: `["null", "string"]`,
+			data:   `null`,
+			got:    new(*string),
+			want:   (*string)(nil),
+		},
+		{
+			name:   "Union Primitive",
+			schema: `["null", "string"]`,
+			data:   `{"string": "foo"}`,
+			got:    new(map[string]interface{}),
+			want:   map[string]interface{}{"string": "foo"},
+		},
+		{
+			name:   "Union Named",
+			schema: `["null", {"type":"enum", "name":"test", "namespace": "org.hamba.avro", "symbols": ["foo", "bar"]}]`,
+			data:   `{"org.hamba.avro.test": "bar"}`,
+			got:    new(map[string]interface{}),
+			want:   map[string]interface{}{"org.hamba.avro.test": "bar"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := avro.MustParse(tt.schema)
+
+			err := avro.UnmarshalJSON(schema, []byte(tt.data), tt.got)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, reflect.ValueOf(tt.got).Elem().Interface())
+		})
+	}
+}
+
+func TestUnmarshalJSON_Error(t *testing.T) {
+	defer ConfigTeardown()
+
+	tests := []struct {
+		name   string
+		schema string
+		data   string
+	}{
+		{
+			name:   "Invalid JSON",
+			schema: `"int"`,
+			data:   `{`,
+		},
+		{
+			name:   "Int Type Mismatch",
+			schema: `"int"`,
+			data:   `"foo"`,
+		},
+		{
+			name:   "Int Overflow",
+			schema: `"int"`,
+			data:   `2147483648`,
+		},
+		{
+			name:   "Bytes Invalid Code Point",
+			schema: `"bytes"`,
+			data:   `"€"`,
+		},
+		{
+			name:   "Fixed Size Mismatch",
+			schema: `{"type":"fixed", "name":"test", "size": 2}`,
+			data:   `"f"`,
+		},
+		{
+			name:   "Enum Unknown Symbol",
+			schema: `{"type":"enum", "name":"test", "symbols": ["foo", "bar"]}`,
+			data:   `"baz"`,
+		},
+		{
+			name:   "Record Missing Field",
+			schema: `{"type":"record", "name":"test", "fields":[{"name": "a", "type": "long"}, {"name": "b", "type": "string"}]}`,
+			data:   `{"b": "foo"}`,
+		},
+		{
+			name:   "Union Not Nullable",
+			schema: `["int", "string"]`,
+			data:   `null`,
+		},
+		{
+			name:   "Union Unwrapped Value",
+			schema: `["null", "string"]`,
+			data:   `"foo"`,
+		},
+		{
+			name:   "Union Unknown Type",
+			schema: `["null", "string"]`,
+			data:   `{"int": 1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := avro.MustParse(tt.schema)
+
+			var got interface{}
+			err := avro.UnmarshalJSON(schema, []byte(tt.data), &got)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJSONEncoder(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse(`{"type":"record", "name":"test", "fields":[{"name": "a", "type": "long"}, {"name": "b", "type": "string"}]}`)
+	buf := &bytes.Buffer{}
+	enc := avro.NewJSONEncoder(schema, buf)
+
+	err := enc.Encode(TestRecord{A: 27, B: "foo"})
+	assert.NoError(t, err)
+	err = enc.Encode(TestRecord{A: 28, B: "bar"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "{\"a\":27,\"b\":\"foo\"}\n{\"a\":28,\"b\":\"bar\"}\n", buf.String())
+}
+
+func TestJSONDecoder(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse(`{"type":"record", "name":"test", "fields":[{"name": "a", "type": "long"}, {"name": "b", "type": "string"}]}`)
+	dec := avro.NewJSONDecoder(schema, bytes.NewBufferString("{\"a\":27,\"b\":\"foo\"}\n{\"a\":28,\"b\":\"bar\"}\n"))
+
+	var got []TestRecord
+	for {
+		var rec TestRecord
+		err := dec.Decode(&rec)
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		got = append(got, rec)
+	}
+
+	assert.Equal(t, []TestRecord{{A: 27, B: "foo"}, {A: 28, B: "bar"}}, got)
+}
+
+func TestJSON_RoundTrip(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": ["null", "long", {"type": "enum", "name": "sym", "symbols": ["foo"]}]},
+		{"name": "b", "type": {"type": "array", "items": "bytes"}}
+	]
+}`)
+	data := `{"a":{"sym":"foo"},"b":["foo","\u0000"]}`
+
+	var got map[string]interface{}
+	err := avro.UnmarshalJSON(schema, []byte(data), &got)
+	assert.NoError(t, err)
+
+	b, err := avro.MarshalJSON(schema, got)
+	assert.NoError(t, err)
+	assert.Equal(t, data, string(b))
+}
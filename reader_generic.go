@@ -35,19 +35,39 @@ func (r *Reader) ReadNext(schema Schema) interface{} {
 			case TimeMicros:
 				return time.Duration(r.ReadLong()) * time.Microsecond
 
-			case TimestampMillis:
+			case TimestampMillis, LocalTimestampMillis:
 				return time.Unix(0, r.ReadLong()*int64(time.Millisecond)).UTC()
 
-			case TimestampMicros:
+			case TimestampMicros, LocalTimestampMicros:
 				return time.Unix(0, r.ReadLong()*int64(time.Microsecond)).UTC()
+
+			case TimestampNanos, LocalTimestampNanos:
+				return time.Unix(0, r.ReadLong()).UTC()
 			}
 		}
 		return r.ReadLong()
 
 	case Float:
+		switch getEncodedType(schema) {
+		case Int:
+			return float32(r.ReadInt())
+
+		case Long:
+			return float32(r.ReadLong())
+		}
 		return r.ReadFloat()
 
 	case Double:
+		switch getEncodedType(schema) {
+		case Int:
+			return float64(r.ReadInt())
+
+		case Long:
+			return float64(r.ReadLong())
+
+		case Float:
+			return float64(r.ReadFloat())
+		}
 		return r.ReadDouble()
 
 	case String:
@@ -64,7 +84,22 @@ func (r *Reader) ReadNext(schema Schema) interface{} {
 		fields := schema.(*RecordSchema).Fields()
 		obj := make(map[string]interface{}, len(fields))
 		for _, field := range fields {
-			obj[field.Name()] = r.ReadNext(field.Type())
+			switch field.action {
+			case fieldActionDrain:
+				createSkipDecoder(field.Type()).Decode(nil, r)
+
+			case fieldActionSetDefault:
+				if field.encodedDef == nil {
+					continue
+				}
+
+				reader := r.cfg.borrowReader(field.encodedDef)
+				obj[field.Name()] = reader.ReadNext(field.Type())
+				r.cfg.returnReader(reader)
+
+			default:
+				obj[field.Name()] = r.ReadNext(field.Type())
+			}
 		}
 		return obj
 
@@ -72,7 +107,7 @@ func (r *Reader) ReadNext(schema Schema) interface{} {
 		return r.ReadNext(schema.(*RefSchema).Schema())
 
 	case Enum:
-		symbols := schema.(*EnumSchema).Symbols()
+		symbols := schema.(*EnumSchema).decodedSymbols()
 		idx := int(r.ReadInt())
 		if idx < 0 || idx >= len(symbols) {
 			r.ReportError("Read", "unknown enum symbol")
@@ -99,24 +134,30 @@ func (r *Reader) ReadNext(schema Schema) interface{} {
 		return obj
 
 	case Union:
-		types := schema.(*UnionSchema).Types()
-		idx := int(r.ReadLong())
-		if idx < 0 || idx > len(types)-1 {
-			r.ReportError("Read", "unknown union type")
+		union := schema.(*UnionSchema)
+		_, schema := getUnionSchema(union, r)
+		if schema == nil {
 			return nil
 		}
-		schema := types[idx]
 		if schema.Type() == Null {
 			return nil
 		}
 
+		if union.flat {
+			return r.ReadNext(schema)
+		}
+
 		key := schemaTypeName(schema)
 		obj := map[string]interface{}{}
-		obj[key] = r.ReadNext(types[idx])
+		obj[key] = r.ReadNext(schema)
 
 		return obj
 
 	case Fixed:
+		if ls != nil && ls.Type() == Duration {
+			return readDuration(r)
+		}
+
 		size := schema.(*FixedSchema).Size()
 		obj := make([]byte, size)
 		r.Read(obj)
@@ -75,6 +75,34 @@ func TestReader_ReadNext(t *testing.T) {
 			want:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
 			wantErr: false,
 		},
+		{
+			name:    "Long Timestamp-Nanos",
+			data:    []byte{0x8C, 0xC8, 0xB1, 0x82, 0xBD, 0xB5, 0xF9, 0xE5, 0x2B},
+			schema:  `{"type":"long","logicalType":"timestamp-nanos"}`,
+			want:    time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "Long Local-Timestamp-Millis",
+			data:    []byte{0x90, 0xB2, 0xAE, 0xC3, 0xEC, 0x5B},
+			schema:  `{"type":"long","logicalType":"local-timestamp-millis"}`,
+			want:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "Long Local-Timestamp-Micros",
+			data:    []byte{0x80, 0xCD, 0xB7, 0xA2, 0xEE, 0xC7, 0xCD, 0x05},
+			schema:  `{"type":"long","logicalType":"local-timestamp-micros"}`,
+			want:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "Long Local-Timestamp-Nanos",
+			data:    []byte{0x8C, 0xC8, 0xB1, 0x82, 0xBD, 0xB5, 0xF9, 0xE5, 0x2B},
+			schema:  `{"type":"long","logicalType":"local-timestamp-nanos"}`,
+			want:    time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+			wantErr: false,
+		},
 		{
 			name:    "Float",
 			data:    []byte{0x33, 0x33, 0x93, 0x3F},
@@ -194,6 +222,13 @@ func TestReader_ReadNext(t *testing.T) {
 			want:    big.NewRat(1734, 5),
 			wantErr: false,
 		},
+		{
+			name:    "Fixed Duration",
+			data:    []byte{0x0C, 0x00, 0x00, 0x00, 0x22, 0x00, 0x00, 0x00, 0x52, 0xAA, 0x08, 0x00},
+			schema:  `{"type":"fixed", "name": "test", "size": 12,"logicalType":"duration"}`,
+			want:    avro.LogicalDuration{Months: 12, Days: 34, Milliseconds: 567890},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
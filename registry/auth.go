@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net/http"
+)
+
+// TokenSource provides the bearer tokens used to authenticate with the registry.
+type TokenSource interface {
+	// Token returns the token to authenticate a request with. Refresh is true when the
+	// registry rejected the previous token, and a new token should be obtained.
+	Token(ctx context.Context, refresh bool) (string, error)
+}
+
+// TokenSourceFunc is an adapter to allow the use of ordinary functions as a TokenSource.
+type TokenSourceFunc func(ctx context.Context, refresh bool) (string, error)
+
+// Token returns the token to authenticate a request with.
+func (f TokenSourceFunc) Token(ctx context.Context, refresh bool) (string, error) {
+	return f(ctx, refresh)
+}
+
+// WithBearerToken sets a static bearer token to authenticate requests with.
+func WithBearerToken(token string) ClientFunc {
+	return WithTokenSource(TokenSourceFunc(func(context.Context, bool) (string, error) {
+		return token, nil
+	}))
+}
+
+// WithTokenSource sets the source of the bearer tokens to authenticate requests with.
+//
+// When the registry responds with 401 Unauthorized, a token is requested with refresh
+// set and the request is retried once.
+func WithTokenSource(ts TokenSource) ClientFunc {
+	return func(c *Client) {
+		c.tokens = ts
+	}
+}
+
+// WithHeader adds a header sent with every request.
+func WithHeader(key, value string) ClientFunc {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
+}
+
+// NewTLSTransport returns an http transport authenticating with the client certificate
+// and key in the given PEM files. The transport is otherwise configured as the default
+// transport of the Client.
+//
+// If caFile is not empty, the server certificate is verified against the CA certificates
+// in the PEM file, instead of the system roots.
+func NewTLSTransport(certFile, keyFile, caFile string) (*http.Transport, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if caFile != "" {
+		b, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, errors.New("registry: no CA certificates found in " + caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	// The transport keeps the timeouts of the default client transport.
+	t := defaultClient.Transport.(*http.Transport).Clone()
+	t.TLSClientConfig = cfg
+
+	return t, nil
+}
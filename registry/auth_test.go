@@ -0,0 +1,233 @@
+package registry_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hamba/avro/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_BearerToken(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL, registry.WithBearerToken("token"))
+
+	_, err := client.GetSubjects()
+
+	assert.NoError(t, err)
+}
+
+func TestClient_TokenSourceRefreshesOnUnauthorized(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"id":10}`))
+	}))
+	defer s.Close()
+	var refreshes int
+	ts := registry.TokenSourceFunc(func(ctx context.Context, refresh bool) (string, error) {
+		if refresh {
+			refreshes++
+			return "fresh", nil
+		}
+		return "stale", nil
+	})
+	client, _ := registry.NewClient(s.URL, registry.WithTokenSource(ts))
+
+	id, _, err := client.CreateSchema("test", `"string"`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, id)
+	assert.Equal(t, 1, refreshes)
+}
+
+func TestClient_TokenSourceUnauthorized(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL, registry.WithBearerToken("token"))
+
+	_, err := client.GetSubjects()
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.(registry.Error).StatusCode)
+	assert.Equal(t, 2, count)
+}
+
+func TestClient_TokenSourceError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer s.Close()
+	ts := registry.TokenSourceFunc(func(ctx context.Context, refresh bool) (string, error) {
+		return "", errors.New("test error")
+	})
+	client, _ := registry.NewClient(s.URL, registry.WithTokenSource(ts))
+
+	_, err := client.GetSubjects()
+
+	assert.Error(t, err)
+}
+
+func TestClient_WithHeader(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"foo", "bar"}, r.Header["X-Test"])
+		assert.Equal(t, "tenant", r.Header.Get("X-Tenant"))
+		assert.Equal(t, "application/vnd.schemaregistry.v1+json", r.Header.Get("Content-Type"))
+
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL,
+		registry.WithHeader("X-Test", "foo"),
+		registry.WithHeader("X-Test", "bar"),
+		registry.WithHeader("X-Tenant", "tenant"),
+	)
+
+	_, err := client.GetSubjects()
+
+	assert.NoError(t, err)
+}
+
+func TestNewTLSTransport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	ca, caKey := writeCert(t, dir, "ca", nil, nil)
+	_, _ = writeCert(t, dir, "server", ca, caKey)
+	_, _ = writeCert(t, dir, "client", ca, caKey)
+
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Len(t, r.TLS.PeerCertificates, 1)
+
+		_, _ = w.Write([]byte(`["foobar"]`))
+	}))
+	serverCert, err := tls.LoadX509KeyPair(filepath.Join(dir, "server.pem"), filepath.Join(dir, "server-key.pem"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	s.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	s.StartTLS()
+	defer s.Close()
+
+	transport, err := registry.NewTLSTransport(
+		filepath.Join(dir, "client.pem"),
+		filepath.Join(dir, "client-key.pem"),
+		filepath.Join(dir, "ca.pem"),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+	client, _ := registry.NewClient(s.URL, registry.WithHTTPClient(&http.Client{Transport: transport}))
+
+	subjects, err := client.GetSubjects()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foobar"}, subjects)
+	assert.Equal(t, 3*time.Second, transport.TLSHandshakeTimeout)
+}
+
+func TestNewTLSTransport_CertError(t *testing.T) {
+	_, err := registry.NewTLSTransport("missing.pem", "missing-key.pem", "")
+
+	assert.Error(t, err)
+}
+
+func TestNewTLSTransport_CAError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	_, _ = writeCert(t, dir, "client", nil, nil)
+	_ = ioutil.WriteFile(filepath.Join(dir, "ca.pem"), []byte("not a certificate"), 0600)
+
+	_, err = registry.NewTLSTransport(
+		filepath.Join(dir, "client.pem"),
+		filepath.Join(dir, "client-key.pem"),
+		filepath.Join(dir, "ca.pem"),
+	)
+
+	assert.Error(t, err)
+}
+
+// writeCert writes a certificate and key signed by the parent, or self signed
+// when parent is nil, to PEM files in dir.
+func writeCert(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err = ioutil.WriteFile(filepath.Join(dir, name+".pem"), certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, name+"-key.pem"), keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, key
+}
@@ -3,18 +3,21 @@ Package registry implements a Confluent Schema Registry compliant client.
 
 See the Confluent Schema Registry docs for an understanding of the
 API: https://docs.confluent.io/current/schema-registry/docs/api.html
-
 */
 package registry
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
+	"math/rand"
 	"net"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/hamba/avro"
@@ -53,41 +56,162 @@ type Registry interface {
 	IsRegistered(subject string, schema string) (int, avro.Schema, error)
 }
 
+// ContextRegistry represents a schema registry with context aware methods.
+//
+// Functions taking a Registry use the context aware methods when the registry implements them.
+type ContextRegistry interface {
+	Registry
+
+	// GetSchemaContext returns the schema with the given id.
+	GetSchemaContext(ctx context.Context, id int) (avro.Schema, error)
+
+	// GetSubjectsContext gets the registry subjects.
+	GetSubjectsContext(ctx context.Context) ([]string, error)
+
+	// GetVersionsContext gets the schema versions for a subject.
+	GetVersionsContext(ctx context.Context, subject string) ([]int, error)
+
+	// GetSchemaByVersionContext gets the schema by version.
+	GetSchemaByVersionContext(ctx context.Context, subject string, version int) (avro.Schema, error)
+
+	// GetLatestSchemaContext gets the latest schema for a subject.
+	GetLatestSchemaContext(ctx context.Context, subject string) (avro.Schema, error)
+
+	// GetLatestSchemaInfoContext gets the latest schema and schema metadata for a subject.
+	GetLatestSchemaInfoContext(ctx context.Context, subject string) (SchemaInfo, error)
+
+	// CreateSchemaContext creates a schema in the registry, returning the schema id.
+	CreateSchemaContext(ctx context.Context, subject string, schema string) (int, avro.Schema, error)
+
+	// IsRegisteredContext determines of the schema is registered.
+	IsRegisteredContext(ctx context.Context, subject string, schema string) (int, avro.Schema, error)
+}
+
+// ReferenceRegistry represents a schema registry registering schemas that reference
+// schemas of other subjects.
+type ReferenceRegistry interface {
+	Registry
+
+	// CreateSchemaWithReferences creates a schema with references in the registry, returning the schema id.
+	CreateSchemaWithReferences(subject string, schema string, refs []SchemaReference) (int, avro.Schema, error)
+
+	// CreateSchemaWithReferencesContext creates a schema with references in the registry, returning the schema id.
+	CreateSchemaWithReferencesContext(ctx context.Context, subject string, schema string, refs []SchemaReference) (int, avro.Schema, error)
+
+	// IsRegisteredWithReferences determines of the schema with references is registered.
+	IsRegisteredWithReferences(subject string, schema string, refs []SchemaReference) (int, avro.Schema, error)
+
+	// IsRegisteredWithReferencesContext determines of the schema with references is registered.
+	IsRegisteredWithReferencesContext(ctx context.Context, subject string, schema string, refs []SchemaReference) (int, avro.Schema, error)
+}
+
+// getSchema returns the schema with the given id, using the context when the registry supports it.
+func getSchema(ctx context.Context, reg Registry, id int) (avro.Schema, error) {
+	if r, ok := reg.(ContextRegistry); ok {
+		return r.GetSchemaContext(ctx, id)
+	}
+	return reg.GetSchema(id)
+}
+
+// getVersions gets the schema versions for a subject, using the context when the registry supports it.
+func getVersions(ctx context.Context, reg Registry, subject string) ([]int, error) {
+	if r, ok := reg.(ContextRegistry); ok {
+		return r.GetVersionsContext(ctx, subject)
+	}
+	return reg.GetVersions(subject)
+}
+
+// getSchemaByVersion gets the schema by version, using the context when the registry supports it.
+func getSchemaByVersion(ctx context.Context, reg Registry, subject string, version int) (avro.Schema, error) {
+	if r, ok := reg.(ContextRegistry); ok {
+		return r.GetSchemaByVersionContext(ctx, subject, version)
+	}
+	return reg.GetSchemaByVersion(subject, version)
+}
+
+// LatestVersion is the version number used to address the latest version of a subject.
+const LatestVersion = -1
+
+// CompatibilityLevel is a schema compatibility level.
+type CompatibilityLevel string
+
+// Compatibility levels.
+const (
+	None               CompatibilityLevel = "NONE"
+	Backward           CompatibilityLevel = "BACKWARD"
+	BackwardTransitive CompatibilityLevel = "BACKWARD_TRANSITIVE"
+	Forward            CompatibilityLevel = "FORWARD"
+	ForwardTransitive  CompatibilityLevel = "FORWARD_TRANSITIVE"
+	Full               CompatibilityLevel = "FULL"
+	FullTransitive     CompatibilityLevel = "FULL_TRANSITIVE"
+)
+
+// Mode is a registry mode.
+type Mode string
+
+// Registry modes.
+const (
+	ReadWrite Mode = "READWRITE"
+	ReadOnly  Mode = "READONLY"
+	Import    Mode = "IMPORT"
+)
+
+// SubjectVersion represents a subject and version pair.
+type SubjectVersion struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// SchemaReference represents a reference to a schema registered under another subject.
+type SchemaReference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
 type schemaPayload struct {
-	Schema string `json:"schema"`
+	Schema     string            `json:"schema"`
+	References []SchemaReference `json:"references,omitempty"`
 }
 
 type idPayload struct {
 	ID int `json:"id"`
 }
 
+type compatibilityPayload struct {
+	IsCompatible bool `json:"is_compatible"`
+}
+
+type configPayload struct {
+	Compatibility CompatibilityLevel `json:"compatibility,omitempty"`
+	Level         CompatibilityLevel `json:"compatibilityLevel,omitempty"`
+}
+
+type modePayload struct {
+	Mode Mode `json:"mode"`
+}
+
 type credentials struct {
 	username string
 	password string
 }
 
 type schemaInfoPayload struct {
-	Schema  string `json:"schema"`
-	ID      int    `json:"id"`
-	Version int    `json:"version"`
-}
-
-// Parse converts the string schema registry response into a
-// SchemaInfo object with an avro.Schema schema.
-func (s *schemaInfoPayload) Parse() (info SchemaInfo, err error) {
-	info = SchemaInfo{
-		ID:      s.ID,
-		Version: s.Version,
-	}
-	info.Schema, err = avro.Parse(s.Schema)
-	return info, err
+	Schema     string            `json:"schema"`
+	ID         int               `json:"id"`
+	Version    int               `json:"version"`
+	References []SchemaReference `json:"references"`
 }
 
 // SchemaInfo represents a schema and metadata information.
 type SchemaInfo struct {
-	Schema  avro.Schema
-	ID      int
-	Version int
+	Schema     avro.Schema
+	ID         int
+	Version    int
+	References []SchemaReference
+
+	// Raw is the schema text as registered.
+	Raw string
 }
 
 var defaultClient = &http.Client{
@@ -118,28 +242,117 @@ func WithBasicAuth(username string, password string) ClientFunc {
 	}
 }
 
+// WithRetries sets the number of times an idempotent request is retried after a
+// network error or a 5xx or 429 response.
+//
+// By default requests are not retried.
+func WithRetries(retries int) ClientFunc {
+	return func(c *Client) {
+		c.retries = retries
+	}
+}
+
+// WithBackoff sets the minimum and maximum delay between retries.
+//
+// The delay doubles with each retry, starting at min and capped at max, with
+// random jitter of up to half the delay.
+func WithBackoff(min, max time.Duration) ClientFunc {
+	return func(c *Client) {
+		c.minBackoff = min
+		c.maxBackoff = max
+	}
+}
+
+// WithLatestTTL sets the duration the latest version of a subject is cached for.
+//
+// By default the latest version of a subject is not cached.
+func WithLatestTTL(ttl time.Duration) ClientFunc {
+	return func(c *Client) {
+		c.latestTTL = ttl
+	}
+}
+
+type versionKey struct {
+	subject string
+	version int
+}
+
+type versionEntry struct {
+	info    schemaInfoPayload
+	schema  avro.Schema
+	expires time.Time
+}
+
+// schemaKey identifies a schema by its text as sent to the registry, as schemas
+// with the same canonical form can differ in their defaults and docs.
+type schemaKey struct {
+	subject string
+	schema  string
+	refs    string
+}
+
+type idEntry struct {
+	id     int
+	schema avro.Schema
+}
+
 // Client is an HTTP registry client.
+//
+// Requests are sent to the first available base url. When a request to a base url
+// fails with a network error or a 5xx or 429 response, the remaining base urls are
+// tried in turn, and the url that last succeeded is used for subsequent requests.
+//
+// Each schema returned by the client is parsed into its own schema cache, so named
+// types from different registry schemas never shadow each other or those in
+// avro.DefaultSchemaCache.
+//
+// Schemas are cached by id, subject and version, and subject and schema text.
+// Lookups of the latest version of a subject are only cached when a TTL is set
+// with WithLatestTTL.
 type Client struct {
 	client *http.Client
-	base   string
+	bases  []string
+	active uint32 // Index of the base url that last succeeded.
+
+	creds   credentials
+	tokens  TokenSource
+	headers http.Header
+
+	retries    int
+	minBackoff time.Duration
+	maxBackoff time.Duration
 
-	creds credentials
+	latestTTL time.Duration
+	now       func() time.Time
 
-	cache *concurrent.Map // map[int]avro.Schema
+	cache    *concurrent.Map // map[int]avro.Schema
+	versions *concurrent.Map // map[versionKey]versionEntry
+	ids      *concurrent.Map // map[schemaKey]idEntry
 }
 
 // NewClient creates a schema registry Client with the given base url.
+//
+// The base url may be a comma separated list of urls of the same registry cluster.
 func NewClient(baseURL string, opts ...ClientFunc) (*Client, error) {
-	_, err := url.Parse(baseURL)
-	if err != nil {
-		return nil, err
+	var bases []string
+	for _, u := range strings.Split(baseURL, ",") {
+		u = strings.TrimSpace(u)
+		if _, err := url.Parse(u); err != nil {
+			return nil, err
+		}
+		bases = append(bases, strings.TrimSuffix(u, "/"))
 	}
-	baseURL = strings.TrimSuffix(baseURL, "/")
 
 	c := &Client{
-		client: defaultClient,
-		base:   baseURL,
-		cache:  concurrent.NewMap(),
+		client:     defaultClient,
+		bases:      bases,
+		headers:    http.Header{},
+		minBackoff: 100 * time.Millisecond,
+		maxBackoff: 10 * time.Second,
+		now:        time.Now,
+		cache:      concurrent.NewMap(),
+		versions:   concurrent.NewMap(),
+		ids:        concurrent.NewMap(),
 	}
 
 	for _, opt := range opts {
@@ -154,17 +367,25 @@ func NewClient(baseURL string, opts ...ClientFunc) (*Client, error) {
 // GetSchema will cache the schema in memory after it is successfully returned,
 // allowing it to be used efficiently in a high load situation.
 func (c *Client) GetSchema(id int) (avro.Schema, error) {
+	return c.GetSchemaContext(context.Background(), id)
+}
+
+// GetSchemaContext returns the schema with the given id.
+//
+// GetSchemaContext will cache the schema in memory after it is successfully returned,
+// allowing it to be used efficiently in a high load situation.
+func (c *Client) GetSchemaContext(ctx context.Context, id int) (avro.Schema, error) {
 	if schema, ok := c.cache.Load(id); ok {
 		return schema.(avro.Schema), nil
 	}
 
 	var payload schemaPayload
-	err := c.request(http.MethodGet, "/schemas/ids/"+strconv.Itoa(id), nil, &payload)
+	err := c.request(ctx, http.MethodGet, "/schemas/ids/"+strconv.Itoa(id), nil, &payload)
 	if err != nil {
 		return nil, err
 	}
 
-	schema, err := avro.Parse(payload.Schema)
+	schema, err := c.parseSchema(ctx, payload.Schema, payload.References)
 	if err != nil {
 		return nil, err
 	}
@@ -176,8 +397,13 @@ func (c *Client) GetSchema(id int) (avro.Schema, error) {
 
 // GetSubjects gets the registry subjects.
 func (c *Client) GetSubjects() ([]string, error) {
+	return c.GetSubjectsContext(context.Background())
+}
+
+// GetSubjectsContext gets the registry subjects.
+func (c *Client) GetSubjectsContext(ctx context.Context) ([]string, error) {
 	var subjects []string
-	err := c.request(http.MethodGet, "/subjects", nil, &subjects)
+	err := c.request(ctx, http.MethodGet, "/subjects", nil, &subjects)
 	if err != nil {
 		return nil, err
 	}
@@ -187,8 +413,13 @@ func (c *Client) GetSubjects() ([]string, error) {
 
 // GetVersions gets the schema versions for a subject.
 func (c *Client) GetVersions(subject string) ([]int, error) {
+	return c.GetVersionsContext(context.Background(), subject)
+}
+
+// GetVersionsContext gets the schema versions for a subject.
+func (c *Client) GetVersionsContext(ctx context.Context, subject string) ([]int, error) {
 	var versions []int
-	err := c.request(http.MethodGet, "/subjects/"+subject+"/versions", nil, &versions)
+	err := c.request(ctx, http.MethodGet, "/subjects/"+subject+"/versions", nil, &versions)
 	if err != nil {
 		return nil, err
 	}
@@ -198,90 +429,546 @@ func (c *Client) GetVersions(subject string) ([]int, error) {
 
 // GetSchemaByVersion gets the schema by version.
 func (c *Client) GetSchemaByVersion(subject string, version int) (avro.Schema, error) {
-	var payload schemaPayload
-	err := c.request(http.MethodGet, "/subjects/"+subject+"/versions/"+strconv.Itoa(version), nil, &payload)
+	return c.GetSchemaByVersionContext(context.Background(), subject, version)
+}
+
+// GetSchemaByVersionContext gets the schema by version.
+func (c *Client) GetSchemaByVersionContext(ctx context.Context, subject string, version int) (avro.Schema, error) {
+	entry, err := c.getVersion(ctx, subject, version)
 	if err != nil {
 		return nil, err
 	}
 
-	return avro.Parse(payload.Schema)
+	return entry.schema, nil
 }
 
 // GetLatestSchema gets the latest schema for a subject.
 func (c *Client) GetLatestSchema(subject string) (avro.Schema, error) {
-	var payload schemaPayload
-	err := c.request(http.MethodGet, "/subjects/"+subject+"/versions/latest", nil, &payload)
+	return c.GetLatestSchemaContext(context.Background(), subject)
+}
+
+// GetLatestSchemaContext gets the latest schema for a subject.
+func (c *Client) GetLatestSchemaContext(ctx context.Context, subject string) (avro.Schema, error) {
+	entry, err := c.getVersion(ctx, subject, LatestVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	return avro.Parse(payload.Schema)
+	return entry.schema, nil
 }
 
 // GetLatestSchemaInfo gets the latest schema and schema metadata for a subject.
 func (c *Client) GetLatestSchemaInfo(subject string) (SchemaInfo, error) {
-	var payload schemaInfoPayload
-	err := c.request(http.MethodGet, "/subjects/"+subject+"/versions/latest", nil, &payload)
+	return c.GetLatestSchemaInfoContext(context.Background(), subject)
+}
+
+// GetLatestSchemaInfoContext gets the latest schema and schema metadata for a subject.
+func (c *Client) GetLatestSchemaInfoContext(ctx context.Context, subject string) (SchemaInfo, error) {
+	return c.GetSchemaInfoContext(ctx, subject, LatestVersion)
+}
+
+// GetSchemaInfo gets the schema and schema metadata for a subject version.
+func (c *Client) GetSchemaInfo(subject string, version int) (SchemaInfo, error) {
+	return c.GetSchemaInfoContext(context.Background(), subject, version)
+}
+
+// GetSchemaInfoContext gets the schema and schema metadata for a subject version.
+func (c *Client) GetSchemaInfoContext(ctx context.Context, subject string, version int) (SchemaInfo, error) {
+	entry, err := c.getVersion(ctx, subject, version)
 	if err != nil {
 		return SchemaInfo{}, err
 	}
 
-	return payload.Parse()
+	return SchemaInfo{
+		Schema:     entry.schema,
+		ID:         entry.info.ID,
+		Version:    entry.info.Version,
+		References: entry.info.References,
+		Raw:        entry.info.Schema,
+	}, nil
 }
 
 // CreateSchema creates a schema in the registry, returning the schema id.
 func (c *Client) CreateSchema(subject string, schema string) (int, avro.Schema, error) {
-	var payload idPayload
-	err := c.request(http.MethodPost, "/subjects/"+subject+"/versions", schemaPayload{Schema: schema}, &payload)
-	if err != nil {
-		return 0, nil, err
-	}
+	return c.CreateSchemaWithReferencesContext(context.Background(), subject, schema, nil)
+}
 
-	sch, err := avro.Parse(schema)
-	return payload.ID, sch, err
+// CreateSchemaContext creates a schema in the registry, returning the schema id.
+func (c *Client) CreateSchemaContext(ctx context.Context, subject string, schema string) (int, avro.Schema, error) {
+	return c.CreateSchemaWithReferencesContext(ctx, subject, schema, nil)
+}
+
+// CreateSchemaWithReferences creates a schema with references in the registry, returning the schema id.
+func (c *Client) CreateSchemaWithReferences(subject string, schema string, refs []SchemaReference) (int, avro.Schema, error) {
+	return c.CreateSchemaWithReferencesContext(context.Background(), subject, schema, refs)
+}
+
+// CreateSchemaWithReferencesContext creates a schema with references in the registry, returning the schema id.
+func (c *Client) CreateSchemaWithReferencesContext(ctx context.Context, subject string, schema string, refs []SchemaReference) (int, avro.Schema, error) {
+	return c.lookupID(ctx, http.MethodPost, "/subjects/"+subject+"/versions", subject, schema, refs)
 }
 
 // IsRegistered determines of the schema is registered.
 func (c *Client) IsRegistered(subject string, schema string) (int, avro.Schema, error) {
+	return c.IsRegisteredWithReferencesContext(context.Background(), subject, schema, nil)
+}
+
+// IsRegisteredContext determines of the schema is registered.
+func (c *Client) IsRegisteredContext(ctx context.Context, subject string, schema string) (int, avro.Schema, error) {
+	return c.IsRegisteredWithReferencesContext(ctx, subject, schema, nil)
+}
+
+// IsRegisteredWithReferences determines of the schema with references is registered.
+func (c *Client) IsRegisteredWithReferences(subject string, schema string, refs []SchemaReference) (int, avro.Schema, error) {
+	return c.IsRegisteredWithReferencesContext(context.Background(), subject, schema, refs)
+}
+
+// IsRegisteredWithReferencesContext determines of the schema with references is registered.
+func (c *Client) IsRegisteredWithReferencesContext(ctx context.Context, subject string, schema string, refs []SchemaReference) (int, avro.Schema, error) {
+	return c.lookupID(ctx, http.MethodPost, "/subjects/"+subject, subject, schema, refs)
+}
+
+// TestCompatibility determines if the schema is compatible with the given subject version.
+func (c *Client) TestCompatibility(subject string, version int, schema string, refs ...SchemaReference) (bool, error) {
+	return c.TestCompatibilityContext(context.Background(), subject, version, schema, refs...)
+}
+
+// TestCompatibilityContext determines if the schema is compatible with the given subject version.
+func (c *Client) TestCompatibilityContext(ctx context.Context, subject string, version int, schema string, refs ...SchemaReference) (bool, error) {
+	var payload compatibilityPayload
+	uri := "/compatibility/subjects/" + subject + "/versions/" + versionPath(version)
+	err := c.do(ctx, http.MethodPost, uri, schemaPayload{Schema: schema, References: refs}, &payload, true)
+	if err != nil {
+		return false, err
+	}
+
+	return payload.IsCompatible, nil
+}
+
+// GetGlobalCompatibilityLevel gets the global compatibility level.
+func (c *Client) GetGlobalCompatibilityLevel() (CompatibilityLevel, error) {
+	return c.GetGlobalCompatibilityLevelContext(context.Background())
+}
+
+// GetGlobalCompatibilityLevelContext gets the global compatibility level.
+func (c *Client) GetGlobalCompatibilityLevelContext(ctx context.Context) (CompatibilityLevel, error) {
+	var payload configPayload
+	err := c.request(ctx, http.MethodGet, "/config", nil, &payload)
+	if err != nil {
+		return "", err
+	}
+
+	return payload.Level, nil
+}
+
+// SetGlobalCompatibilityLevel sets the global compatibility level.
+func (c *Client) SetGlobalCompatibilityLevel(lvl CompatibilityLevel) error {
+	return c.SetGlobalCompatibilityLevelContext(context.Background(), lvl)
+}
+
+// SetGlobalCompatibilityLevelContext sets the global compatibility level.
+func (c *Client) SetGlobalCompatibilityLevelContext(ctx context.Context, lvl CompatibilityLevel) error {
+	var payload configPayload
+	return c.request(ctx, http.MethodPut, "/config", configPayload{Compatibility: lvl}, &payload)
+}
+
+// GetCompatibilityLevel gets the compatibility level of a subject.
+func (c *Client) GetCompatibilityLevel(subject string) (CompatibilityLevel, error) {
+	return c.GetCompatibilityLevelContext(context.Background(), subject)
+}
+
+// GetCompatibilityLevelContext gets the compatibility level of a subject.
+func (c *Client) GetCompatibilityLevelContext(ctx context.Context, subject string) (CompatibilityLevel, error) {
+	var payload configPayload
+	err := c.request(ctx, http.MethodGet, "/config/"+subject, nil, &payload)
+	if err != nil {
+		return "", err
+	}
+
+	return payload.Level, nil
+}
+
+// SetCompatibilityLevel sets the compatibility level of a subject.
+func (c *Client) SetCompatibilityLevel(subject string, lvl CompatibilityLevel) error {
+	return c.SetCompatibilityLevelContext(context.Background(), subject, lvl)
+}
+
+// SetCompatibilityLevelContext sets the compatibility level of a subject.
+func (c *Client) SetCompatibilityLevelContext(ctx context.Context, subject string, lvl CompatibilityLevel) error {
+	var payload configPayload
+	return c.request(ctx, http.MethodPut, "/config/"+subject, configPayload{Compatibility: lvl}, &payload)
+}
+
+// DeleteSubject deletes a subject, returning the deleted versions.
+//
+// A subject must be soft deleted before it can be permanently deleted.
+func (c *Client) DeleteSubject(subject string, permanent bool) ([]int, error) {
+	return c.DeleteSubjectContext(context.Background(), subject, permanent)
+}
+
+// DeleteSubjectContext deletes a subject, returning the deleted versions.
+//
+// A subject must be soft deleted before it can be permanently deleted.
+func (c *Client) DeleteSubjectContext(ctx context.Context, subject string, permanent bool) ([]int, error) {
+	c.InvalidateSubject(subject)
+
+	var versions []int
+	err := c.request(ctx, http.MethodDelete, "/subjects/"+subject+permanentQuery(permanent), nil, &versions)
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// DeleteSchemaVersion deletes a version of a subject, returning the deleted version.
+//
+// A version must be soft deleted before it can be permanently deleted.
+func (c *Client) DeleteSchemaVersion(subject string, version int, permanent bool) (int, error) {
+	return c.DeleteSchemaVersionContext(context.Background(), subject, version, permanent)
+}
+
+// DeleteSchemaVersionContext deletes a version of a subject, returning the deleted version.
+//
+// A version must be soft deleted before it can be permanently deleted.
+func (c *Client) DeleteSchemaVersionContext(ctx context.Context, subject string, version int, permanent bool) (int, error) {
+	c.InvalidateSubject(subject)
+
+	var deleted int
+	uri := "/subjects/" + subject + "/versions/" + versionPath(version) + permanentQuery(permanent)
+	err := c.request(ctx, http.MethodDelete, uri, nil, &deleted)
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// GetSchemaSubjectVersions gets the subject versions registered with the schema id.
+func (c *Client) GetSchemaSubjectVersions(id int) ([]SubjectVersion, error) {
+	return c.GetSchemaSubjectVersionsContext(context.Background(), id)
+}
+
+// GetSchemaSubjectVersionsContext gets the subject versions registered with the schema id.
+func (c *Client) GetSchemaSubjectVersionsContext(ctx context.Context, id int) ([]SubjectVersion, error) {
+	var versions []SubjectVersion
+	err := c.request(ctx, http.MethodGet, "/schemas/ids/"+strconv.Itoa(id)+"/versions", nil, &versions)
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// GetGlobalMode gets the global registry mode.
+func (c *Client) GetGlobalMode() (Mode, error) {
+	return c.GetGlobalModeContext(context.Background())
+}
+
+// GetGlobalModeContext gets the global registry mode.
+func (c *Client) GetGlobalModeContext(ctx context.Context) (Mode, error) {
+	var payload modePayload
+	err := c.request(ctx, http.MethodGet, "/mode", nil, &payload)
+	if err != nil {
+		return "", err
+	}
+
+	return payload.Mode, nil
+}
+
+// SetGlobalMode sets the global registry mode.
+func (c *Client) SetGlobalMode(mode Mode) error {
+	return c.SetGlobalModeContext(context.Background(), mode)
+}
+
+// SetGlobalModeContext sets the global registry mode.
+func (c *Client) SetGlobalModeContext(ctx context.Context, mode Mode) error {
+	var payload modePayload
+	return c.request(ctx, http.MethodPut, "/mode", modePayload{Mode: mode}, &payload)
+}
+
+// GetMode gets the registry mode of a subject.
+func (c *Client) GetMode(subject string) (Mode, error) {
+	return c.GetModeContext(context.Background(), subject)
+}
+
+// GetModeContext gets the registry mode of a subject.
+func (c *Client) GetModeContext(ctx context.Context, subject string) (Mode, error) {
+	var payload modePayload
+	err := c.request(ctx, http.MethodGet, "/mode/"+subject, nil, &payload)
+	if err != nil {
+		return "", err
+	}
+
+	return payload.Mode, nil
+}
+
+// SetMode sets the registry mode of a subject.
+func (c *Client) SetMode(subject string, mode Mode) error {
+	return c.SetModeContext(context.Background(), subject, mode)
+}
+
+// SetModeContext sets the registry mode of a subject.
+func (c *Client) SetModeContext(ctx context.Context, subject string, mode Mode) error {
+	var payload modePayload
+	return c.request(ctx, http.MethodPut, "/mode/"+subject, modePayload{Mode: mode}, &payload)
+}
+
+// InvalidateSubject removes the cached versions and schema ids of a subject.
+func (c *Client) InvalidateSubject(subject string) {
+	c.versions.Range(func(key, _ interface{}) bool {
+		if key.(versionKey).subject == subject {
+			c.versions.Delete(key)
+		}
+		return true
+	})
+	c.ids.Range(func(key, _ interface{}) bool {
+		if key.(schemaKey).subject == subject {
+			c.ids.Delete(key)
+		}
+		return true
+	})
+}
+
+// InvalidateAll removes all cached schemas, versions and schema ids.
+func (c *Client) InvalidateAll() {
+	for _, m := range []*concurrent.Map{c.cache, c.versions, c.ids} {
+		m.Range(func(key, _ interface{}) bool {
+			m.Delete(key)
+			return true
+		})
+	}
+}
+
+// getVersion gets a subject version, using the cache when possible.
+func (c *Client) getVersion(ctx context.Context, subject string, version int) (versionEntry, error) {
+	key := versionKey{subject: subject, version: version}
+	if v, ok := c.versions.Load(key); ok {
+		entry := v.(versionEntry)
+		if entry.expires.IsZero() || c.now().Before(entry.expires) {
+			return entry, nil
+		}
+	}
+
+	var payload schemaInfoPayload
+	err := c.request(ctx, http.MethodGet, "/subjects/"+subject+"/versions/"+versionPath(version), nil, &payload)
+	if err != nil {
+		return versionEntry{}, err
+	}
+
+	schema, err := c.parseSchema(ctx, payload.Schema, payload.References)
+	if err != nil {
+		return versionEntry{}, err
+	}
+
+	entry := versionEntry{info: payload, schema: schema}
+	if version != LatestVersion {
+		c.versions.Store(key, entry)
+		return entry, nil
+	}
+
+	if payload.Version > 0 {
+		c.versions.Store(versionKey{subject: subject, version: payload.Version}, entry)
+	}
+	if c.latestTTL > 0 {
+		entry.expires = c.now().Add(c.latestTTL)
+		c.versions.Store(key, entry)
+	}
+
+	return entry, nil
+}
+
+// lookupID gets the id of a subject schema, using the cache when possible.
+func (c *Client) lookupID(ctx context.Context, method, uri, subject, schema string, refs []SchemaReference) (int, avro.Schema, error) {
+	key := schemaKey{subject: subject, schema: schema, refs: fmt.Sprint(refs)}
+	if v, ok := c.ids.Load(key); ok {
+		entry := v.(idEntry)
+		return entry.id, entry.schema, nil
+	}
+
+	// Registering a schema is idempotent, the registry returns the existing id.
 	var payload idPayload
-	err := c.request(http.MethodPost, "/subjects/"+subject, schemaPayload{Schema: schema}, &payload)
+	err := c.do(ctx, method, uri, schemaPayload{Schema: schema, References: refs}, &payload, true)
 	if err != nil {
 		return 0, nil, err
 	}
 
-	sch, err := avro.Parse(schema)
-	return payload.ID, sch, err
+	sch, err := c.parseSchema(ctx, schema, refs)
+	if err != nil {
+		return payload.ID, nil, err
+	}
+
+	c.ids.Store(key, idEntry{id: payload.ID, schema: sch})
+
+	return payload.ID, sch, nil
 }
 
-func (c *Client) request(method, uri string, in, out interface{}) error {
-	var body io.Reader
+// parseSchema parses the schema into an isolated schema cache, after fetching and
+// parsing the schemas it references.
+func (c *Client) parseSchema(ctx context.Context, schema string, refs []SchemaReference) (avro.Schema, error) {
+	return ParseWithReferences(schema, refs, func(ref SchemaReference) (string, []SchemaReference, error) {
+		entry, err := c.getVersion(ctx, ref.Subject, ref.Version)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return entry.info.Schema, entry.info.References, nil
+	})
+}
+
+func versionPath(version int) string {
+	if version == LatestVersion {
+		return "latest"
+	}
+
+	return strconv.Itoa(version)
+}
+
+func permanentQuery(permanent bool) string {
+	if permanent {
+		return "?permanent=true"
+	}
+
+	return ""
+}
+
+func (c *Client) request(ctx context.Context, method, uri string, in, out interface{}) error {
+	return c.do(ctx, method, uri, in, out, method != http.MethodPost)
+}
+
+// do performs the request, failing over across the base urls and retrying
+// retryable failures of idempotent requests.
+func (c *Client) do(ctx context.Context, method, uri string, in, out interface{}, idempotent bool) error {
+	var b []byte
 	if in != nil {
-		b, _ := jsoniter.Marshal(in)
-		body = bytes.NewReader(b)
+		b, _ = jsoniter.Marshal(in)
 	}
 
-	req, _ := http.NewRequest(method, c.base+uri, body) // This error is not possible as we already parsed the url
-	req.Header.Set("Content-Type", contentType)
+	var err error
+	for attempt := 0; ; attempt++ {
+		start := int(atomic.LoadUint32(&c.active))
+		for i := range c.bases {
+			idx := (start + i) % len(c.bases)
+
+			var retryable bool
+			retryable, err = c.send(ctx, c.bases[idx], method, uri, b, out)
+			if err == nil {
+				atomic.StoreUint32(&c.active, uint32(idx))
+				return nil
+			}
+			if !retryable || !idempotent || ctx.Err() != nil {
+				return err
+			}
+		}
+
+		if attempt >= c.retries {
+			return err
+		}
+
+		timer := time.NewTimer(c.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
 
-	if len(c.creds.username) > 0 || len(c.creds.password) > 0 {
-		req.SetBasicAuth(c.creds.username, c.creds.password)
+// send sends a single request, returning if a failed request can be retried.
+func (c *Client) send(ctx context.Context, base, method, uri string, in []byte, out interface{}) (bool, error) {
+	var body io.Reader
+	if in != nil {
+		body = bytes.NewReader(in)
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.roundTrip(ctx, base, method, uri, body, false)
 	if err != nil {
-		return err
+		return true, err
+	}
+
+	// A rejected token is refreshed once before the request is given up on.
+	if resp.StatusCode == http.StatusUnauthorized && c.tokens != nil {
+		_ = resp.Body.Close()
+
+		if in != nil {
+			body = bytes.NewReader(in)
+		}
+		resp, err = c.roundTrip(ctx, base, method, uri, body, true)
+		if err != nil {
+			return true, err
+		}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
 		err := Error{StatusCode: resp.StatusCode}
 		_ = jsoniter.NewDecoder(resp.Body).Decode(&err)
-		return err
+		return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests, err
+	}
+
+	return false, jsoniter.NewDecoder(resp.Body).Decode(out)
+}
+
+func (c *Client) roundTrip(ctx context.Context, base, method, uri string, body io.Reader, refresh bool) (*http.Response, error) {
+	req, _ := http.NewRequest(method, base+uri, body) // This error is not possible as we already parsed the url
+	req = req.WithContext(ctx)
+	for k, v := range c.headers {
+		req.Header[k] = v
 	}
+	req.Header.Set("Content-Type", contentType)
 
-	return jsoniter.NewDecoder(resp.Body).Decode(out)
+	if len(c.creds.username) > 0 || len(c.creds.password) > 0 {
+		req.SetBasicAuth(c.creds.username, c.creds.password)
+	}
+
+	if c.tokens != nil {
+		token, err := c.tokens.Token(ctx, refresh)
+		if err != nil {
+			return nil, fmt.Errorf("registry: unable to get token: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.client.Do(req)
 }
 
+// backoff returns the delay before the given retry attempt.
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.minBackoff
+	for i := 0; i < attempt && d < c.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > c.maxBackoff {
+		d = c.maxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// Error codes returned by the registry.
+const (
+	CodeSubjectNotFound          = 40401
+	CodeVersionNotFound          = 40402
+	CodeSchemaNotFound           = 40403
+	CodeSubjectSoftDeleted       = 40404
+	CodeSubjectNotSoftDeleted    = 40405
+	CodeVersionSoftDeleted       = 40406
+	CodeVersionNotSoftDeleted    = 40407
+	CodeSubjectLevelConfigNotSet = 40408
+	CodeSubjectLevelModeNotSet   = 40409
+	CodeIncompatibleSchema       = 409
+	CodeInvalidSchema            = 42201
+	CodeInvalidVersion           = 42202
+	CodeInvalidCompatibility     = 42203
+	CodeInvalidMode              = 42204
+	CodeOperationNotPermitted    = 42205
+	CodeBackendDataStore         = 50001
+)
+
 // Error is returned by the registry when there is an error.
 type Error struct {
 	StatusCode int `json:"-"`
@@ -2,7 +2,9 @@ package registry
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -22,3 +24,51 @@ func TestNewClient_WithBasicAuth(t *testing.T) {
 
 	assert.Equal(t, client.creds, creds)
 }
+
+func TestClient_GetLatestSchemaTTLExpires(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		_, _ = w.Write([]byte(`{"subject":"foobar","version":5,"id":2,"schema":"[\"null\",\"string\",\"int\"]"}`))
+	}))
+	defer s.Close()
+	now := time.Now()
+	client, _ := NewClient(s.URL, WithLatestTTL(time.Minute))
+	client.now = func() time.Time { return now }
+
+	_, _ = client.GetLatestSchema("foobar")
+	now = now.Add(30 * time.Second)
+	_, _ = client.GetLatestSchema("foobar")
+	assert.Equal(t, 1, count)
+
+	now = now.Add(time.Minute)
+	_, _ = client.GetLatestSchema("foobar")
+	assert.Equal(t, 2, count)
+}
+
+func TestNewClient_MultipleURLs(t *testing.T) {
+	client, _ := NewClient("http://a.example.com/, http://b.example.com")
+
+	assert.Equal(t, []string{"http://a.example.com", "http://b.example.com"}, client.bases)
+}
+
+func TestClient_Backoff(t *testing.T) {
+	client, _ := NewClient("http://example.com", WithBackoff(100*time.Millisecond, time.Second))
+
+	tests := []struct {
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{attempt: 0, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{attempt: 1, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{attempt: 3, min: 400 * time.Millisecond, max: 800 * time.Millisecond},
+		{attempt: 10, min: 500 * time.Millisecond, max: time.Second},
+	}
+
+	for _, tt := range tests {
+		got := client.backoff(tt.attempt)
+
+		assert.True(t, got >= tt.min && got <= tt.max, "attempt %d: %s", tt.attempt, got)
+	}
+}
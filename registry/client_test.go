@@ -1,10 +1,15 @@
 package registry_test
 
 import (
+	"context"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/hamba/avro"
 	"github.com/hamba/avro/registry"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,6 +19,8 @@ func TestNewClient(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.Implements(t, (*registry.Registry)(nil), client)
+	assert.Implements(t, (*registry.ContextRegistry)(nil), client)
+	assert.Implements(t, (*registry.ReferenceRegistry)(nil), client)
 }
 
 func TestNewClient_UrlError(t *testing.T) {
@@ -22,6 +29,12 @@ func TestNewClient_UrlError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestNewClient_MultipleUrlError(t *testing.T) {
+	_, err := registry.NewClient("http://example.com,://")
+
+	assert.Error(t, err)
+}
+
 func TestClient_PopulatesError(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add("Content-Type", "application/vnd.schemaregistry.v1+json")
@@ -59,6 +72,20 @@ func TestNewClient_BasicAuth(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestClient_RequestWithContext(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetSubjectsContext(ctx)
+
+	assert.Error(t, err)
+}
+
 func TestClient_GetSchema(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "GET", r.Method)
@@ -504,3 +531,787 @@ func TestError_ErrorEmptyMEssage(t *testing.T) {
 
 	assert.Equal(t, "registry error: 404", str)
 }
+
+func TestClient_TestCompatibility(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/compatibility/subjects/test/versions/2", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"is_compatible":true}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	ok, err := client.TestCompatibility("test", 2, "[\"null\",\"string\",\"int\"]")
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestClient_TestCompatibilityLatest(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/compatibility/subjects/test/versions/latest", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"is_compatible":false}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	ok, err := client.TestCompatibility("test", registry.LatestVersion, "[\"null\",\"string\",\"int\"]")
+
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestClient_TestCompatibilityRequestError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.TestCompatibility("test", 2, "[\"null\",\"string\",\"int\"]")
+
+	assert.Error(t, err)
+}
+
+func TestClient_GetGlobalCompatibilityLevel(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/config", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"compatibilityLevel":"FULL"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	lvl, err := client.GetGlobalCompatibilityLevel()
+
+	assert.NoError(t, err)
+	assert.Equal(t, registry.Full, lvl)
+}
+
+func TestClient_GetGlobalCompatibilityLevelRequestError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.GetGlobalCompatibilityLevel()
+
+	assert.Error(t, err)
+}
+
+func TestClient_SetGlobalCompatibilityLevel(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/config", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"compatibility":"BACKWARD"}`, string(body))
+
+		_, _ = w.Write([]byte(`{"compatibility":"BACKWARD"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	err := client.SetGlobalCompatibilityLevel(registry.Backward)
+
+	assert.NoError(t, err)
+}
+
+func TestClient_GetCompatibilityLevel(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/config/test", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"compatibilityLevel":"FORWARD_TRANSITIVE"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	lvl, err := client.GetCompatibilityLevel("test")
+
+	assert.NoError(t, err)
+	assert.Equal(t, registry.ForwardTransitive, lvl)
+}
+
+func TestClient_GetCompatibilityLevelRequestError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.GetCompatibilityLevel("test")
+
+	assert.Error(t, err)
+}
+
+func TestClient_SetCompatibilityLevel(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/config/test", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"compatibility":"NONE"}`, string(body))
+
+		_, _ = w.Write([]byte(`{"compatibility":"NONE"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	err := client.SetCompatibilityLevel("test", registry.None)
+
+	assert.NoError(t, err)
+}
+
+func TestClient_DeleteSubject(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/subjects/test", r.URL.Path)
+		assert.Equal(t, "", r.URL.RawQuery)
+
+		_, _ = w.Write([]byte(`[1,2]`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	versions, err := client.DeleteSubject("test", false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+}
+
+func TestClient_DeleteSubjectPermanent(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/subjects/test", r.URL.Path)
+		assert.Equal(t, "permanent=true", r.URL.RawQuery)
+
+		_, _ = w.Write([]byte(`[1,2]`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	versions, err := client.DeleteSubject("test", true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+}
+
+func TestClient_DeleteSubjectRequestError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.DeleteSubject("test", false)
+
+	assert.Error(t, err)
+}
+
+func TestClient_DeleteSchemaVersion(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/subjects/test/versions/2", r.URL.Path)
+		assert.Equal(t, "permanent=true", r.URL.RawQuery)
+
+		_, _ = w.Write([]byte(`2`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	version, err := client.DeleteSchemaVersion("test", 2, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, version)
+}
+
+func TestClient_DeleteSchemaVersionRequestError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.DeleteSchemaVersion("test", 2, false)
+
+	assert.Error(t, err)
+}
+
+func TestClient_GetSchemaSubjectVersions(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/schemas/ids/5/versions", r.URL.Path)
+
+		_, _ = w.Write([]byte(`[{"subject":"foo","version":1},{"subject":"bar","version":3}]`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	versions, err := client.GetSchemaSubjectVersions(5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []registry.SubjectVersion{{Subject: "foo", Version: 1}, {Subject: "bar", Version: 3}}, versions)
+}
+
+func TestClient_GetSchemaSubjectVersionsRequestError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.GetSchemaSubjectVersions(5)
+
+	assert.Error(t, err)
+}
+
+func TestClient_GetGlobalMode(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/mode", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"mode":"READWRITE"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	mode, err := client.GetGlobalMode()
+
+	assert.NoError(t, err)
+	assert.Equal(t, registry.ReadWrite, mode)
+}
+
+func TestClient_GetGlobalModeRequestError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.GetGlobalMode()
+
+	assert.Error(t, err)
+}
+
+func TestClient_SetGlobalMode(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/mode", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"mode":"READONLY"}`, string(body))
+
+		_, _ = w.Write([]byte(`{"mode":"READONLY"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	err := client.SetGlobalMode(registry.ReadOnly)
+
+	assert.NoError(t, err)
+}
+
+func TestClient_GetMode(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/mode/test", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"mode":"IMPORT"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	mode, err := client.GetMode("test")
+
+	assert.NoError(t, err)
+	assert.Equal(t, registry.Import, mode)
+}
+
+func TestClient_GetModeRequestError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.GetMode("test")
+
+	assert.Error(t, err)
+}
+
+func TestClient_SetMode(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/mode/test", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"mode":"READWRITE"}`, string(body))
+
+		_, _ = w.Write([]byte(`{"mode":"READWRITE"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	err := client.SetMode("test", registry.ReadWrite)
+
+	assert.NoError(t, err)
+}
+
+func TestClient_GetSchemaWithReferences(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+
+		switch r.URL.Path {
+		case "/schemas/ids/5":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"Order\",\"namespace\":\"com.acme\",\"fields\":[{\"name\":\"price\",\"type\":\"Money\"}]}","references":[{"name":"com.acme.Money","subject":"money","version":2}]}`))
+		case "/subjects/money/versions/2":
+			_, _ = w.Write([]byte(`{"subject":"money","version":2,"id":3,"schema":"{\"type\":\"record\",\"name\":\"Money\",\"namespace\":\"com.acme\",\"fields\":[{\"name\":\"amount\",\"type\":\"Amount\"}]}","references":[{"name":"com.acme.Amount","subject":"amount","version":1}]}`))
+		case "/subjects/amount/versions/1":
+			_, _ = w.Write([]byte(`{"subject":"amount","version":1,"id":2,"schema":"{\"type\":\"fixed\",\"name\":\"Amount\",\"namespace\":\"com.acme\",\"size\":8}"}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(404)
+		}
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	schema, err := client.GetSchema(5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"com.acme.Order","type":"record","fields":[{"name":"price","type":"com.acme.Money"}]}`, schema.String())
+	money := schema.(*avro.RecordSchema).Fields()[0].Type().(*avro.RefSchema).Schema()
+	assert.Equal(t, `{"name":"com.acme.Money","type":"record","fields":[{"name":"amount","type":{"name":"com.acme.Amount","type":"fixed","size":8}}]}`, money.String())
+}
+
+func TestClient_GetSchemaWithReferencesRequestError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/schemas/ids/5" {
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"Order\",\"namespace\":\"com.acme\",\"fields\":[{\"name\":\"price\",\"type\":\"Money\"}]}","references":[{"name":"com.acme.Money","subject":"money","version":2}]}`))
+			return
+		}
+		w.WriteHeader(404)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.GetSchema(5)
+
+	assert.Error(t, err)
+}
+
+func TestClient_GetLatestSchemaInfoWithReferences(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/subjects/order/versions/latest":
+			_, _ = w.Write([]byte(`{"subject":"order","version":4,"id":5,"schema":"{\"type\":\"array\",\"items\":\"com.acme.Amount\"}","references":[{"name":"com.acme.Amount","subject":"amount","version":1}]}`))
+		case "/subjects/amount/versions/1":
+			_, _ = w.Write([]byte(`{"subject":"amount","version":1,"id":2,"schema":"{\"type\":\"fixed\",\"name\":\"Amount\",\"namespace\":\"com.acme\",\"size\":8}"}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	info, err := client.GetLatestSchemaInfo("order")
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"array","items":{"name":"com.acme.Amount","type":"fixed","size":8}}`, info.Schema.String())
+	assert.Equal(t, 5, info.ID)
+	assert.Equal(t, 4, info.Version)
+	assert.Equal(t, []registry.SchemaReference{{Name: "com.acme.Amount", Subject: "amount", Version: 1}}, info.References)
+}
+
+func TestClient_CreateSchemaWithReferences(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/subjects/order/versions":
+			assert.Equal(t, "POST", r.Method)
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, `{"schema":"{\"type\":\"array\",\"items\":\"com.acme.Amount\"}","references":[{"name":"com.acme.Amount","subject":"amount","version":1}]}`, string(body))
+
+			_, _ = w.Write([]byte(`{"id":10}`))
+		case "/subjects/amount/versions/1":
+			_, _ = w.Write([]byte(`{"subject":"amount","version":1,"id":2,"schema":"{\"type\":\"fixed\",\"name\":\"Amount\",\"namespace\":\"com.acme\",\"size\":8}"}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	refs := []registry.SchemaReference{{Name: "com.acme.Amount", Subject: "amount", Version: 1}}
+
+	id, schema, err := client.CreateSchemaWithReferences("order", `{"type":"array","items":"com.acme.Amount"}`, refs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, id)
+	assert.Equal(t, `{"type":"array","items":{"name":"com.acme.Amount","type":"fixed","size":8}}`, schema.String())
+}
+
+func TestClient_GetSchemaIsolatesNamedTypes(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schemas/ids/1":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"Isolated\",\"namespace\":\"org.hamba.avro.registry\",\"fields\":[{\"name\":\"a\",\"type\":\"int\"}]}"}`))
+		case "/schemas/ids/2":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"Isolated\",\"namespace\":\"org.hamba.avro.registry\",\"fields\":[{\"name\":\"b\",\"type\":\"string\"}]}"}`))
+		case "/schemas/ids/3":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"array\",\"items\":\"org.hamba.avro.registry.Isolated\"}"}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	schema1, err := client.GetSchema(1)
+	assert.NoError(t, err)
+	schema2, err := client.GetSchema(2)
+	assert.NoError(t, err)
+	_, err = client.GetSchema(3)
+
+	assert.Error(t, err)
+	assert.Equal(t, "a", schema1.(*avro.RecordSchema).Fields()[0].Name())
+	assert.Equal(t, "b", schema2.(*avro.RecordSchema).Fields()[0].Name())
+	assert.Nil(t, avro.DefaultSchemaCache.Get("org.hamba.avro.registry.Isolated"))
+}
+
+func TestClient_GetSchemaByVersionCachesSchema(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		_, _ = w.Write([]byte(`{"subject":"foobar","version":5,"id":2,"schema":"[\"null\",\"string\",\"int\"]"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, _ = client.GetSchemaByVersion("foobar", 5)
+
+	_, _ = client.GetSchemaByVersion("foobar", 5)
+
+	assert.Equal(t, 1, count)
+}
+
+func TestClient_GetLatestSchemaDoesNotCacheByDefault(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		_, _ = w.Write([]byte(`{"subject":"foobar","version":5,"id":2,"schema":"[\"null\",\"string\",\"int\"]"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, _ = client.GetLatestSchema("foobar")
+
+	_, _ = client.GetLatestSchema("foobar")
+
+	assert.Equal(t, 2, count)
+}
+
+func TestClient_GetLatestSchemaCachesVersion(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		_, _ = w.Write([]byte(`{"subject":"foobar","version":5,"id":2,"schema":"[\"null\",\"string\",\"int\"]"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, _ = client.GetLatestSchema("foobar")
+
+	_, _ = client.GetSchemaByVersion("foobar", 5)
+
+	assert.Equal(t, 1, count)
+}
+
+func TestClient_GetLatestSchemaWithLatestTTL(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		_, _ = w.Write([]byte(`{"subject":"foobar","version":5,"id":2,"schema":"[\"null\",\"string\",\"int\"]"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL, registry.WithLatestTTL(time.Minute))
+
+	_, _ = client.GetLatestSchema("foobar")
+
+	info, err := client.GetLatestSchemaInfo("foobar")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, 2, info.ID)
+	assert.Equal(t, 5, info.Version)
+}
+
+func TestClient_CreateSchemaCachesID(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		_, _ = w.Write([]byte(`{"id":10}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, _, _ = client.CreateSchema("foobar", `["null","string","int"]`)
+
+	id, _, err := client.IsRegistered("foobar", `["null","string","int"]`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, 10, id)
+}
+
+func TestClient_CreateSchemaDoesNotCacheByCanonicalForm(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		_, _ = w.Write([]byte(`{"id":` + strconv.Itoa(10+count) + `}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, _, _ = client.CreateSchema("foobar", `{"type":"record","name":"test","fields":[{"name":"a","type":"int","default":1}]}`)
+
+	id, _, err := client.CreateSchema("foobar", `{"type":"record","name":"test","fields":[{"name":"a","type":"int","default":2}]}`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 12, id)
+}
+
+func TestClient_IsRegisteredCachesIDPerSubject(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		_, _ = w.Write([]byte(`{"id":10}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, _, _ = client.IsRegistered("foo", `["null","string","int"]`)
+
+	_, _, _ = client.IsRegistered("bar", `["null","string","int"]`)
+
+	assert.Equal(t, 2, count)
+}
+
+func TestClient_IsRegisteredDoesNotCacheErrors(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.WriteHeader(404)
+		_, _ = w.Write([]byte(`{"error_code": 40403, "message": "Schema not found"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+
+	_, _, _ = client.IsRegistered("foobar", `["null","string","int"]`)
+
+	_, _, _ = client.IsRegistered("foobar", `["null","string","int"]`)
+
+	assert.Equal(t, 2, count)
+}
+
+func TestClient_InvalidateSubject(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		switch r.URL.Path {
+		case "/subjects/foo/versions/1", "/subjects/bar/versions/1":
+			_, _ = w.Write([]byte(`{"subject":"foo","version":1,"id":2,"schema":"[\"null\",\"string\",\"int\"]"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":10}`))
+		}
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	_, _ = client.GetSchemaByVersion("foo", 1)
+	_, _ = client.GetSchemaByVersion("bar", 1)
+	_, _, _ = client.IsRegistered("foo", `"string"`)
+
+	client.InvalidateSubject("foo")
+
+	_, _ = client.GetSchemaByVersion("foo", 1)
+	_, _ = client.GetSchemaByVersion("bar", 1)
+	_, _, _ = client.IsRegistered("foo", `"string"`)
+	assert.Equal(t, 5, count)
+}
+
+func TestClient_InvalidateAll(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		switch r.URL.Path {
+		case "/schemas/ids/2":
+			_, _ = w.Write([]byte(`{"schema":"[\"null\",\"string\",\"int\"]"}`))
+		case "/subjects/foo/versions/1":
+			_, _ = w.Write([]byte(`{"subject":"foo","version":1,"id":2,"schema":"[\"null\",\"string\",\"int\"]"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":10}`))
+		}
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	_, _ = client.GetSchema(2)
+	_, _ = client.GetSchemaByVersion("foo", 1)
+	_, _, _ = client.IsRegistered("foo", `"string"`)
+
+	client.InvalidateAll()
+
+	_, _ = client.GetSchema(2)
+	_, _ = client.GetSchemaByVersion("foo", 1)
+	_, _, _ = client.IsRegistered("foo", `"string"`)
+	assert.Equal(t, 6, count)
+}
+
+func TestClient_DeleteSubjectInvalidatesSubject(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if r.Method == http.MethodDelete {
+			_, _ = w.Write([]byte(`[1]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"subject":"foo","version":1,"id":2,"schema":"[\"null\",\"string\",\"int\"]"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	_, _ = client.GetSchemaByVersion("foo", 1)
+
+	_, _ = client.DeleteSubject("foo", false)
+
+	_, _ = client.GetSchemaByVersion("foo", 1)
+	assert.Equal(t, 3, count)
+}
+
+func TestClient_RetriesServerErrors(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`["foobar"]`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL, registry.WithRetries(2), registry.WithBackoff(time.Millisecond, time.Millisecond))
+
+	subjects, err := client.GetSubjects()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foobar"}, subjects)
+	assert.Equal(t, 3, count)
+}
+
+func TestClient_RetriesTooManyRequests(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count < 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":10}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL, registry.WithRetries(1), registry.WithBackoff(time.Millisecond, time.Millisecond))
+
+	id, _, err := client.CreateSchema("test", `"string"`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, id)
+	assert.Equal(t, 2, count)
+}
+
+func TestClient_RetriesExhausted(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error_code": 50001, "message": "Error in the backend data store"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL, registry.WithRetries(2), registry.WithBackoff(time.Millisecond, time.Millisecond))
+
+	_, err := client.GetSubjects()
+
+	assert.Error(t, err)
+	assert.IsType(t, registry.Error{}, err)
+	assert.Equal(t, 50001, err.(registry.Error).Code)
+	assert.Equal(t, 3, count)
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code": 40401, "message": "Subject not found"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL, registry.WithRetries(2), registry.WithBackoff(time.Millisecond, time.Millisecond))
+
+	_, err := client.GetVersions("foobar")
+
+	assert.Error(t, err)
+	assert.Equal(t, 40401, err.(registry.Error).Code)
+	assert.Equal(t, 1, count)
+}
+
+func TestClient_RetryStopsOnContextCancel(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL, registry.WithRetries(5), registry.WithBackoff(time.Minute, time.Minute))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetSubjectsContext(ctx)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestClient_FailsOverBaseURLs(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		_, _ = w.Write([]byte(`["foobar"]`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(down.URL + "," + s.URL)
+
+	_, err := client.GetSubjects()
+	assert.NoError(t, err)
+
+	subjects, err := client.GetSubjects()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foobar"}, subjects)
+	assert.Equal(t, 2, count)
+}
+
+func TestClient_FailsOverBaseURLsOnServerError(t *testing.T) {
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer unhealthy.Close()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[1, 2]`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(unhealthy.URL + ", " + s.URL + "/")
+
+	versions, err := client.GetVersions("foobar")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+}
@@ -0,0 +1,122 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hamba/avro"
+)
+
+// CompatibilityError is returned when a schema is incompatible with a schema in the history of a subject.
+type CompatibilityError struct {
+	// Version is the version of the incompatible schema.
+	Version int
+	// Err is the reason the schemas are incompatible.
+	Err error
+}
+
+// Error returns the error message.
+func (e CompatibilityError) Error() string {
+	return fmt.Sprintf("registry: schema is incompatible with version %d: %v", e.Version, e.Err)
+}
+
+// Compatible determines if the schema is compatible with the history of schemas according
+// to the compatibility level, as the registry would when the schema is registered.
+//
+// The history is ordered from the oldest to the latest schema, and the version of a schema
+// is its 1-based position in the history.
+func Compatible(lvl CompatibilityLevel, schema avro.Schema, history ...avro.Schema) error {
+	versions := make([]int, len(history))
+	for i := range history {
+		versions[i] = i + 1
+	}
+
+	return compatible(lvl, schema, versions, history)
+}
+
+// CheckCompatibility determines if the schema is compatible with the history of the subject
+// according to the compatibility level, without registering the schema.
+//
+// A subject that does not exist has no history, so any schema is compatible.
+func CheckCompatibility(reg Registry, subject string, schema avro.Schema, lvl CompatibilityLevel) error {
+	return CheckCompatibilityContext(context.Background(), reg, subject, schema, lvl)
+}
+
+// CheckCompatibilityContext determines if the schema is compatible with the history of the subject
+// according to the compatibility level, without registering the schema.
+//
+// A subject that does not exist has no history, so any schema is compatible.
+func CheckCompatibilityContext(ctx context.Context, reg Registry, subject string, schema avro.Schema, lvl CompatibilityLevel) error {
+	if lvl == None {
+		return nil
+	}
+
+	versions, err := getVersions(ctx, reg, subject)
+	if err != nil {
+		if e, ok := err.(Error); ok && e.StatusCode == http.StatusNotFound && e.Code == CodeSubjectNotFound {
+			return nil
+		}
+		return err
+	}
+
+	// Only the latest version is needed unless the level is transitive.
+	if !transitive(lvl) && len(versions) > 1 {
+		versions = versions[len(versions)-1:]
+	}
+
+	history := make([]avro.Schema, len(versions))
+	for i, v := range versions {
+		history[i], err = getSchemaByVersion(ctx, reg, subject, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return compatible(lvl, schema, versions, history)
+}
+
+func compatible(lvl CompatibilityLevel, schema avro.Schema, versions []int, history []avro.Schema) error {
+	var backward, forward bool
+	switch lvl {
+	case None:
+		return nil
+	case Backward, BackwardTransitive:
+		backward = true
+	case Forward, ForwardTransitive:
+		forward = true
+	case Full, FullTransitive:
+		backward, forward = true, true
+	default:
+		return fmt.Errorf("registry: unknown compatibility level %q", lvl)
+	}
+
+	if !transitive(lvl) && len(history) > 1 {
+		versions = versions[len(versions)-1:]
+		history = history[len(history)-1:]
+	}
+
+	// The results are not kept between checks, a schema that was rejected
+	// can be corrected without changing its canonical form.
+	compat := avro.NewSchemaCompatibility()
+
+	// Check the latest schemas first, they are the most likely to be incompatible.
+	for i := len(history) - 1; i >= 0; i-- {
+		var err error
+		if backward {
+			err = compat.Compatible(schema, history[i])
+		}
+		if err == nil && forward {
+			err = compat.Compatible(history[i], schema)
+		}
+		if err != nil {
+			return CompatibilityError{Version: versions[i], Err: err}
+		}
+	}
+
+	return nil
+}
+
+func transitive(lvl CompatibilityLevel) bool {
+	return lvl == BackwardTransitive || lvl == ForwardTransitive || lvl == FullTransitive
+}
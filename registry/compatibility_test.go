@@ -0,0 +1,204 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hamba/avro"
+	"github.com/hamba/avro/registry"
+	"github.com/hamba/avro/registry/registrytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompatible(t *testing.T) {
+	tests := []struct {
+		name        string
+		level       registry.CompatibilityLevel
+		history     []string
+		schema      string
+		wantErr     bool
+		wantVersion int
+	}{
+		{
+			name:    "No History",
+			level:   registry.FullTransitive,
+			history: nil,
+			schema:  `"int"`,
+			wantErr: false,
+		},
+		{
+			name:    "None",
+			level:   registry.None,
+			history: []string{`"string"`},
+			schema:  `"int"`,
+			wantErr: false,
+		},
+		{
+			name:    "Backward",
+			level:   registry.Backward,
+			history: []string{`"string"`, `"int"`},
+			schema:  `"long"`,
+			wantErr: false,
+		},
+		{
+			name:        "Backward Incompatible",
+			level:       registry.Backward,
+			history:     []string{`"string"`, `"long"`},
+			schema:      `"int"`,
+			wantErr:     true,
+			wantVersion: 2,
+		},
+		{
+			name:        "Backward Transitive",
+			level:       registry.BackwardTransitive,
+			history:     []string{`"string"`, `"int"`},
+			schema:      `"long"`,
+			wantErr:     true,
+			wantVersion: 1,
+		},
+		{
+			name:    "Forward",
+			level:   registry.Forward,
+			history: []string{`"string"`, `"long"`},
+			schema:  `"int"`,
+			wantErr: false,
+		},
+		{
+			name:        "Forward Incompatible",
+			level:       registry.Forward,
+			history:     []string{`"int"`},
+			schema:      `"long"`,
+			wantErr:     true,
+			wantVersion: 1,
+		},
+		{
+			name:        "Forward Transitive",
+			level:       registry.ForwardTransitive,
+			history:     []string{`"string"`, `"long"`},
+			schema:      `"int"`,
+			wantErr:     true,
+			wantVersion: 1,
+		},
+		{
+			name:    "Full",
+			level:   registry.Full,
+			history: []string{`{"type":"record","name":"test","fields":[{"name":"a","type":"int"}]}`},
+			schema:  `{"type":"record","name":"test","fields":[{"name":"a","type":"int"},{"name":"b","type":"int","default":1}]}`,
+			wantErr: false,
+		},
+		{
+			name:        "Full Incompatible",
+			level:       registry.Full,
+			history:     []string{`"int"`},
+			schema:      `"long"`,
+			wantErr:     true,
+			wantVersion: 1,
+		},
+		{
+			name:  "Full Transitive",
+			level: registry.FullTransitive,
+			history: []string{
+				`{"type":"record","name":"test","fields":[{"name":"a","type":"int"}]}`,
+				`{"type":"record","name":"test","fields":[{"name":"a","type":"int","default":0},{"name":"b","type":"int","default":1}]}`,
+			},
+			schema:      `{"type":"record","name":"test","fields":[{"name":"b","type":"int","default":1}]}`,
+			wantErr:     true,
+			wantVersion: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := make([]avro.Schema, len(tt.history))
+			for i, s := range tt.history {
+				history[i] = avro.MustParse(s)
+			}
+			schema := avro.MustParse(tt.schema)
+
+			err := registry.Compatible(tt.level, schema, history...)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.IsType(t, registry.CompatibilityError{}, err)
+				assert.Equal(t, tt.wantVersion, err.(registry.CompatibilityError).Version)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCompatible_CorrectedSchema(t *testing.T) {
+	v1 := avro.MustParse(`{"type":"record","name":"test","fields":[{"name":"a","type":"int"}]}`)
+	noDefault := avro.MustParse(`{"type":"record","name":"test","fields":[{"name":"a","type":"int"},{"name":"b","type":"int"}]}`)
+	withDefault := avro.MustParse(`{"type":"record","name":"test","fields":[{"name":"a","type":"int"},{"name":"b","type":"int","default":0}]}`)
+
+	err := registry.Compatible(registry.Backward, noDefault, v1)
+	assert.Error(t, err)
+
+	err = registry.Compatible(registry.Backward, withDefault, v1)
+
+	assert.NoError(t, err)
+}
+
+func TestCompatible_UnknownLevel(t *testing.T) {
+	err := registry.Compatible("foo", avro.MustParse(`"int"`), avro.MustParse(`"int"`))
+
+	assert.Error(t, err)
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	reg := registrytest.NewRegistry()
+	_ = reg.SetCompatibilityLevel("foobar", registry.None)
+	_, _, _ = reg.CreateSchema("foobar", `"string"`)
+	_, _, _ = reg.CreateSchema("foobar", `"int"`)
+	_, _, _ = reg.CreateSchema("foobar", `"string"`)
+	_, _ = reg.DeleteSchemaVersion("foobar", 3, false)
+	schema := avro.MustParse(`"long"`)
+
+	err := registry.CheckCompatibility(reg, "foobar", schema, registry.Backward)
+	assert.NoError(t, err)
+
+	err = registry.CheckCompatibility(reg, "foobar", schema, registry.BackwardTransitive)
+	assert.Error(t, err)
+	assert.Equal(t, 1, err.(registry.CompatibilityError).Version)
+}
+
+func TestCheckCompatibility_SubjectNotFound(t *testing.T) {
+	reg := registrytest.NewRegistry()
+
+	err := registry.CheckCompatibility(reg, "foobar", avro.MustParse(`"long"`), registry.FullTransitive)
+
+	assert.NoError(t, err)
+}
+
+func TestCheckCompatibility_RegistryError(t *testing.T) {
+	reg := &errorRegistry{Registry: registrytest.NewRegistry()}
+
+	err := registry.CheckCompatibility(reg, "foobar", avro.MustParse(`"long"`), registry.Backward)
+
+	assert.Error(t, err)
+}
+
+func TestCheckCompatibility_Client(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	client, _ := registry.NewClient(srv.URL)
+	_ = client.SetCompatibilityLevel("foobar", registry.None)
+	_, _, _ = client.CreateSchema("foobar", `"string"`)
+	_, _, _ = client.CreateSchema("foobar", `"int"`)
+
+	err := registry.CheckCompatibility(client, "foobar", avro.MustParse(`"long"`), registry.BackwardTransitive)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, err.(registry.CompatibilityError).Version)
+}
+
+type errorRegistry struct {
+	*registrytest.Registry
+}
+
+func (r *errorRegistry) GetVersionsContext(ctx context.Context, subject string) ([]int, error) {
+	return nil, errors.New("test error")
+}
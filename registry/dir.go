@@ -0,0 +1,488 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/hamba/avro"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ManifestFile is the name of the manifest file in a registry directory.
+const ManifestFile = "manifest.json"
+
+type manifest struct {
+	Schemas []manifestEntry `json:"schemas"`
+}
+
+type manifestEntry struct {
+	Subject    string            `json:"subject"`
+	Version    int               `json:"version"`
+	ID         int               `json:"id"`
+	References []SchemaReference `json:"references,omitempty"`
+}
+
+// ExportRegistry is a registry that can be exported to a directory.
+type ExportRegistry interface {
+	// GetSubjectsContext gets the registry subjects.
+	GetSubjectsContext(ctx context.Context) ([]string, error)
+
+	// GetVersionsContext gets the schema versions for a subject.
+	GetVersionsContext(ctx context.Context, subject string) ([]int, error)
+
+	// GetSchemaInfoContext gets the schema and schema metadata for a subject version.
+	GetSchemaInfoContext(ctx context.Context, subject string, version int) (SchemaInfo, error)
+}
+
+// DirRegistry is a registry backed by a directory of schema files.
+//
+// Each subject version is stored in the file "<subject>/<version>.avsc", with the subject
+// path escaped. The manifest file maps each subject version to its schema id and references.
+// DirRegistry does not enforce schema compatibility.
+type DirRegistry struct {
+	dir string
+
+	mu       sync.Mutex
+	manifest manifest
+	schemas  map[versionKey]dirSchema
+}
+
+// dirSchema is a schema file and its parsed schema.
+type dirSchema struct {
+	raw    string
+	schema avro.Schema
+}
+
+// NewDirRegistry returns a registry backed by the given directory. If the directory
+// does not contain a manifest, the registry is empty.
+func NewDirRegistry(dir string) (*DirRegistry, error) {
+	r := &DirRegistry{
+		dir:     dir,
+		schemas: map[versionKey]dirSchema{},
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return r, nil
+		}
+		return nil, err
+	}
+
+	if err = jsoniter.Unmarshal(b, &r.manifest); err != nil {
+		return nil, fmt.Errorf("registry: unable to read manifest: %v", err)
+	}
+
+	return r, nil
+}
+
+// Export writes all schema versions of the registry to the directory, in the layout read by DirRegistry.
+//
+// The schemas are written as returned by the registry, keeping their references. Schemas
+// without their registered text are written in canonical form with their references inlined.
+func Export(ctx context.Context, reg ExportRegistry, dir string) error {
+	subjects, err := reg.GetSubjectsContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	r := &DirRegistry{dir: dir, schemas: map[versionKey]dirSchema{}}
+	for _, subject := range subjects {
+		versions, err := reg.GetVersionsContext(ctx, subject)
+		if err != nil {
+			return err
+		}
+
+		for _, version := range versions {
+			info, err := reg.GetSchemaInfoContext(ctx, subject, version)
+			if err != nil {
+				return err
+			}
+
+			entry := manifestEntry{Subject: subject, Version: info.Version, ID: info.ID, References: info.References}
+			raw := info.Raw
+			if raw == "" {
+				entry.References = nil
+				raw = info.Schema.String()
+			}
+			if err = r.writeSchema(entry, raw); err != nil {
+				return err
+			}
+			r.manifest.Schemas = append(r.manifest.Schemas, entry)
+		}
+	}
+
+	return r.writeManifest()
+}
+
+// GetSchema returns the schema with the given id.
+func (r *DirRegistry) GetSchema(id int) (avro.Schema, error) {
+	return r.GetSchemaContext(context.Background(), id)
+}
+
+// GetSchemaContext returns the schema with the given id.
+func (r *DirRegistry) GetSchemaContext(_ context.Context, id int) (avro.Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.manifest.Schemas {
+		if entry.ID == id {
+			s, err := r.schema(entry)
+			if err != nil {
+				return nil, err
+			}
+			return s.schema, nil
+		}
+	}
+
+	return nil, Error{StatusCode: http.StatusNotFound, Code: CodeSchemaNotFound, Message: "Schema not found"}
+}
+
+// GetSubjects gets the registry subjects.
+func (r *DirRegistry) GetSubjects() ([]string, error) {
+	return r.GetSubjectsContext(context.Background())
+}
+
+// GetSubjectsContext gets the registry subjects.
+func (r *DirRegistry) GetSubjectsContext(_ context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := map[string]bool{}
+	subjects := []string{}
+	for _, entry := range r.manifest.Schemas {
+		if !seen[entry.Subject] {
+			seen[entry.Subject] = true
+			subjects = append(subjects, entry.Subject)
+		}
+	}
+	sort.Strings(subjects)
+
+	return subjects, nil
+}
+
+// GetVersions gets the schema versions for a subject.
+func (r *DirRegistry) GetVersions(subject string) ([]int, error) {
+	return r.GetVersionsContext(context.Background(), subject)
+}
+
+// GetVersionsContext gets the schema versions for a subject.
+func (r *DirRegistry) GetVersionsContext(_ context.Context, subject string) ([]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := r.subject(subject)
+	if len(entries) == 0 {
+		return nil, errSubjectNotFound(subject)
+	}
+
+	versions := make([]int, len(entries))
+	for i, entry := range entries {
+		versions[i] = entry.Version
+	}
+
+	return versions, nil
+}
+
+// GetSchemaByVersion gets the schema by version.
+func (r *DirRegistry) GetSchemaByVersion(subject string, version int) (avro.Schema, error) {
+	return r.GetSchemaByVersionContext(context.Background(), subject, version)
+}
+
+// GetSchemaByVersionContext gets the schema by version.
+func (r *DirRegistry) GetSchemaByVersionContext(ctx context.Context, subject string, version int) (avro.Schema, error) {
+	info, err := r.GetSchemaInfoContext(ctx, subject, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return info.Schema, nil
+}
+
+// GetLatestSchema gets the latest schema for a subject.
+func (r *DirRegistry) GetLatestSchema(subject string) (avro.Schema, error) {
+	return r.GetLatestSchemaContext(context.Background(), subject)
+}
+
+// GetLatestSchemaContext gets the latest schema for a subject.
+func (r *DirRegistry) GetLatestSchemaContext(ctx context.Context, subject string) (avro.Schema, error) {
+	return r.GetSchemaByVersionContext(ctx, subject, LatestVersion)
+}
+
+// GetLatestSchemaInfo gets the latest schema and schema metadata for a subject.
+func (r *DirRegistry) GetLatestSchemaInfo(subject string) (SchemaInfo, error) {
+	return r.GetLatestSchemaInfoContext(context.Background(), subject)
+}
+
+// GetLatestSchemaInfoContext gets the latest schema and schema metadata for a subject.
+func (r *DirRegistry) GetLatestSchemaInfoContext(ctx context.Context, subject string) (SchemaInfo, error) {
+	return r.GetSchemaInfoContext(ctx, subject, LatestVersion)
+}
+
+// GetSchemaInfo gets the schema and schema metadata for a subject version.
+func (r *DirRegistry) GetSchemaInfo(subject string, version int) (SchemaInfo, error) {
+	return r.GetSchemaInfoContext(context.Background(), subject, version)
+}
+
+// GetSchemaInfoContext gets the schema and schema metadata for a subject version.
+func (r *DirRegistry) GetSchemaInfoContext(_ context.Context, subject string, version int) (SchemaInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, err := r.version(subject, version)
+	if err != nil {
+		return SchemaInfo{}, err
+	}
+
+	s, err := r.schema(entry)
+	if err != nil {
+		return SchemaInfo{}, err
+	}
+
+	return SchemaInfo{
+		Schema:     s.schema,
+		ID:         entry.ID,
+		Version:    entry.Version,
+		References: entry.References,
+		Raw:        s.raw,
+	}, nil
+}
+
+// CreateSchema creates a schema in the registry, returning the schema id.
+func (r *DirRegistry) CreateSchema(subject string, schema string) (int, avro.Schema, error) {
+	return r.CreateSchemaWithReferencesContext(context.Background(), subject, schema, nil)
+}
+
+// CreateSchemaContext creates a schema in the registry, returning the schema id.
+func (r *DirRegistry) CreateSchemaContext(ctx context.Context, subject string, schema string) (int, avro.Schema, error) {
+	return r.CreateSchemaWithReferencesContext(ctx, subject, schema, nil)
+}
+
+// CreateSchemaWithReferences creates a schema with references in the registry, returning the schema id.
+func (r *DirRegistry) CreateSchemaWithReferences(subject string, schema string, refs []SchemaReference) (int, avro.Schema, error) {
+	return r.CreateSchemaWithReferencesContext(context.Background(), subject, schema, refs)
+}
+
+// CreateSchemaWithReferencesContext creates a schema with references in the registry, returning the schema id.
+//
+// A schema already registered under another subject keeps its id. Schemas are
+// compared on their full text, including defaults and docs, and their references.
+func (r *DirRegistry) CreateSchemaWithReferencesContext(_ context.Context, subject string, schema string, refs []SchemaReference) (int, avro.Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sch, err := r.parse(schema, refs)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	key := SchemaKey(schema, refs)
+	entry := manifestEntry{Subject: subject, Version: 1, References: refs}
+	for _, e := range r.manifest.Schemas {
+		s, err := r.schema(e)
+		if err != nil {
+			return 0, nil, err
+		}
+		if SchemaKey(s.raw, e.References) != key {
+			continue
+		}
+		if e.Subject == subject {
+			return e.ID, s.schema, nil
+		}
+		if entry.ID == 0 {
+			entry.ID = e.ID
+		}
+	}
+
+	var maxID int
+	for _, e := range r.manifest.Schemas {
+		if e.ID > maxID {
+			maxID = e.ID
+		}
+		if e.Subject == subject && e.Version >= entry.Version {
+			entry.Version = e.Version + 1
+		}
+	}
+	if entry.ID == 0 {
+		entry.ID = maxID + 1
+	}
+
+	if err = r.writeSchema(entry, schema); err != nil {
+		return 0, nil, err
+	}
+	r.manifest.Schemas = append(r.manifest.Schemas, entry)
+	if err = r.writeManifest(); err != nil {
+		r.manifest.Schemas = r.manifest.Schemas[:len(r.manifest.Schemas)-1]
+		_ = os.Remove(r.path(entry))
+		return 0, nil, err
+	}
+	r.schemas[versionKey{subject: subject, version: entry.Version}] = dirSchema{raw: schema, schema: sch}
+
+	return entry.ID, sch, nil
+}
+
+// IsRegistered determines of the schema is registered.
+func (r *DirRegistry) IsRegistered(subject string, schema string) (int, avro.Schema, error) {
+	return r.IsRegisteredWithReferencesContext(context.Background(), subject, schema, nil)
+}
+
+// IsRegisteredContext determines of the schema is registered.
+func (r *DirRegistry) IsRegisteredContext(ctx context.Context, subject string, schema string) (int, avro.Schema, error) {
+	return r.IsRegisteredWithReferencesContext(ctx, subject, schema, nil)
+}
+
+// IsRegisteredWithReferences determines of the schema with references is registered.
+func (r *DirRegistry) IsRegisteredWithReferences(subject string, schema string, refs []SchemaReference) (int, avro.Schema, error) {
+	return r.IsRegisteredWithReferencesContext(context.Background(), subject, schema, refs)
+}
+
+// IsRegisteredWithReferencesContext determines of the schema with references is registered.
+func (r *DirRegistry) IsRegisteredWithReferencesContext(_ context.Context, subject string, schema string, refs []SchemaReference) (int, avro.Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := r.subject(subject)
+	if len(entries) == 0 {
+		return 0, nil, errSubjectNotFound(subject)
+	}
+
+	if _, err := r.parse(schema, refs); err != nil {
+		return 0, nil, err
+	}
+
+	key := SchemaKey(schema, refs)
+	for _, entry := range entries {
+		s, err := r.schema(entry)
+		if err != nil {
+			return 0, nil, err
+		}
+		if SchemaKey(s.raw, entry.References) == key {
+			return entry.ID, s.schema, nil
+		}
+	}
+
+	return 0, nil, Error{StatusCode: http.StatusNotFound, Code: CodeSchemaNotFound, Message: "Schema not found"}
+}
+
+// subject returns the manifest entries of a subject ordered by version. The mutex must be held.
+func (r *DirRegistry) subject(subject string) []manifestEntry {
+	var entries []manifestEntry
+	for _, entry := range r.manifest.Schemas {
+		if entry.Subject == subject {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Version < entries[j].Version
+	})
+
+	return entries
+}
+
+// version returns the manifest entry of a subject version. The mutex must be held.
+func (r *DirRegistry) version(subject string, version int) (manifestEntry, error) {
+	entries := r.subject(subject)
+	if len(entries) == 0 {
+		return manifestEntry{}, errSubjectNotFound(subject)
+	}
+
+	if version == LatestVersion {
+		return entries[len(entries)-1], nil
+	}
+	for _, entry := range entries {
+		if entry.Version == version {
+			return entry, nil
+		}
+	}
+
+	msg := "Version " + strconv.Itoa(version) + " not found."
+	return manifestEntry{}, Error{StatusCode: http.StatusNotFound, Code: CodeVersionNotFound, Message: msg}
+}
+
+// schema returns the schema file of a manifest entry. The mutex must be held.
+func (r *DirRegistry) schema(entry manifestEntry) (dirSchema, error) {
+	key := versionKey{subject: entry.Subject, version: entry.Version}
+	if s, ok := r.schemas[key]; ok {
+		return s, nil
+	}
+
+	b, err := ioutil.ReadFile(r.path(entry))
+	if err != nil {
+		return dirSchema{}, err
+	}
+
+	schema, err := r.parse(string(b), entry.References)
+	if err != nil {
+		return dirSchema{}, err
+	}
+	s := dirSchema{raw: string(b), schema: schema}
+	r.schemas[key] = s
+
+	return s, nil
+}
+
+// parse parses the schema, after parsing the schemas it references. The mutex must be held.
+func (r *DirRegistry) parse(schema string, refs []SchemaReference) (avro.Schema, error) {
+	return ParseWithReferences(schema, refs, func(ref SchemaReference) (string, []SchemaReference, error) {
+		entry, err := r.version(ref.Subject, ref.Version)
+		if err != nil {
+			return "", nil, err
+		}
+
+		b, err := ioutil.ReadFile(r.path(entry))
+		if err != nil {
+			return "", nil, err
+		}
+
+		return string(b), entry.References, nil
+	})
+}
+
+func (r *DirRegistry) path(entry manifestEntry) string {
+	return filepath.Join(r.dir, url.PathEscape(entry.Subject), strconv.Itoa(entry.Version)+".avsc")
+}
+
+func (r *DirRegistry) writeSchema(entry manifestEntry, schema string) error {
+	path := r.path(entry)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, []byte(schema), 0644)
+}
+
+// writeManifest writes the manifest sorted by subject and version, leaving the
+// order of the manifest entries unchanged. The mutex must be held.
+func (r *DirRegistry) writeManifest() error {
+	schemas := make([]manifestEntry, len(r.manifest.Schemas))
+	copy(schemas, r.manifest.Schemas)
+	sort.Slice(schemas, func(i, j int) bool {
+		a, b := schemas[i], schemas[j]
+		if a.Subject != b.Subject {
+			return a.Subject < b.Subject
+		}
+		return a.Version < b.Version
+	})
+
+	b, err := jsoniter.MarshalIndent(manifest{Schemas: schemas}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(r.dir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(r.dir, ManifestFile), b, 0644)
+}
+
+func errSubjectNotFound(subject string) error {
+	return Error{StatusCode: http.StatusNotFound, Code: CodeSubjectNotFound, Message: "Subject '" + subject + "' not found."}
+}
@@ -0,0 +1,282 @@
+package registry_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hamba/avro/registry"
+	"github.com/hamba/avro/registry/registrytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirRegistry_ImplementsRegistry(t *testing.T) {
+	reg, err := registry.NewDirRegistry("testdata/dir")
+
+	assert.NoError(t, err)
+	assert.Implements(t, (*registry.Registry)(nil), reg)
+	assert.Implements(t, (*registry.ContextRegistry)(nil), reg)
+	assert.Implements(t, (*registry.ReferenceRegistry)(nil), reg)
+}
+
+func TestNewDirRegistry_ManifestError(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	_ = ioutil.WriteFile(filepath.Join(dir, registry.ManifestFile), []byte("{"), 0644)
+
+	_, err := registry.NewDirRegistry(dir)
+
+	assert.Error(t, err)
+}
+
+func TestDirRegistry_GetSchema(t *testing.T) {
+	reg, _ := registry.NewDirRegistry("testdata/dir")
+
+	schema, err := reg.GetSchema(2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"org.hamba.avro.test","type":"record","fields":[{"name":"a","type":"long"},{"name":"b","type":"string"}]}`, schema.String())
+}
+
+func TestDirRegistry_GetSchemaNotFound(t *testing.T) {
+	reg, _ := registry.NewDirRegistry("testdata/dir")
+
+	_, err := reg.GetSchema(10)
+
+	assert.Error(t, err)
+	assert.Equal(t, registry.CodeSchemaNotFound, err.(registry.Error).Code)
+}
+
+func TestDirRegistry_GetSubjects(t *testing.T) {
+	reg, _ := registry.NewDirRegistry("testdata/dir")
+
+	subjects, err := reg.GetSubjects()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"amount", "foo/bar", "test"}, subjects)
+}
+
+func TestDirRegistry_GetVersions(t *testing.T) {
+	reg, _ := registry.NewDirRegistry("testdata/dir")
+
+	versions, err := reg.GetVersions("test")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+}
+
+func TestDirRegistry_GetVersionsSubjectNotFound(t *testing.T) {
+	reg, _ := registry.NewDirRegistry("testdata/dir")
+
+	_, err := reg.GetVersions("missing")
+
+	assert.Error(t, err)
+	assert.Equal(t, registry.CodeSubjectNotFound, err.(registry.Error).Code)
+}
+
+func TestDirRegistry_GetSchemaByVersion(t *testing.T) {
+	reg, _ := registry.NewDirRegistry("testdata/dir")
+
+	schema, err := reg.GetSchemaByVersion("test", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"org.hamba.avro.test","type":"record","fields":[{"name":"a","type":"long"}]}`, schema.String())
+}
+
+func TestDirRegistry_GetSchemaByVersionNotFound(t *testing.T) {
+	reg, _ := registry.NewDirRegistry("testdata/dir")
+
+	_, err := reg.GetSchemaByVersion("test", 3)
+
+	assert.Error(t, err)
+	assert.Equal(t, registry.CodeVersionNotFound, err.(registry.Error).Code)
+}
+
+func TestDirRegistry_GetSchemaByVersionEscapedSubject(t *testing.T) {
+	reg, _ := registry.NewDirRegistry("testdata/dir")
+
+	schema, err := reg.GetSchemaByVersion("foo/bar", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `"string"`, schema.String())
+}
+
+func TestDirRegistry_GetLatestSchemaInfo(t *testing.T) {
+	reg, _ := registry.NewDirRegistry("testdata/dir")
+
+	info, err := reg.GetLatestSchemaInfo("test")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, info.ID)
+	assert.Equal(t, 2, info.Version)
+}
+
+func TestDirRegistry_GetSchemaInfoReferences(t *testing.T) {
+	reg, _ := registry.NewDirRegistry("testdata/dir")
+
+	info, err := reg.GetSchemaInfo("amount", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, info.ID)
+	assert.Equal(t, []registry.SchemaReference{{Name: "com.acme.Amount", Subject: "amount", Version: 1}}, info.References)
+	assert.Equal(t, `{"type":"array","items":{"name":"com.acme.Amount","type":"fixed","size":8}}`, info.Schema.String())
+}
+
+func TestDirRegistry_IsRegistered(t *testing.T) {
+	reg, _ := registry.NewDirRegistry("testdata/dir")
+
+	id, _, err := reg.IsRegistered("test", `{"type":"record","name":"test","namespace":"org.hamba.avro","fields":[{"name":"a","type":"long"}]}`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+}
+
+func TestDirRegistry_IsRegisteredNotFound(t *testing.T) {
+	reg, _ := registry.NewDirRegistry("testdata/dir")
+
+	_, _, err := reg.IsRegistered("test", `"int"`)
+
+	assert.Error(t, err)
+	assert.Equal(t, registry.CodeSchemaNotFound, err.(registry.Error).Code)
+}
+
+func TestDirRegistry_CreateSchema(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	reg, _ := registry.NewDirRegistry(filepath.Join(dir, "schemas"))
+
+	id1, _, err := reg.CreateSchema("foo", `"string"`)
+	assert.NoError(t, err)
+	id2, _, err := reg.CreateSchema("foo", `"int"`)
+	assert.NoError(t, err)
+	id3, _, err := reg.CreateSchema("bar", `"string"`)
+	assert.NoError(t, err)
+	id4, _, err := reg.CreateSchema("foo", `"string"`)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2, 1, 1}, []int{id1, id2, id3, id4})
+	reg, err = registry.NewDirRegistry(filepath.Join(dir, "schemas"))
+	assert.NoError(t, err)
+	versions, _ := reg.GetVersions("foo")
+	assert.Equal(t, []int{1, 2}, versions)
+	schema, _ := reg.GetSchemaByVersion("foo", 2)
+	assert.Equal(t, `"int"`, schema.String())
+	b, _ := ioutil.ReadFile(filepath.Join(dir, "schemas", "bar", "1.avsc"))
+	assert.Equal(t, `"string"`, string(b))
+}
+
+func TestDirRegistry_CreateSchemaManifestError(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	reg, _ := registry.NewDirRegistry(dir)
+	_, _, _ = reg.CreateSchema("foo", `"string"`)
+	_ = os.Remove(filepath.Join(dir, registry.ManifestFile))
+	_ = os.Mkdir(filepath.Join(dir, registry.ManifestFile), 0755)
+
+	_, _, err := reg.CreateSchema("bar", `"int"`)
+
+	assert.Error(t, err)
+	subjects, _ := reg.GetSubjects()
+	assert.Equal(t, []string{"foo"}, subjects)
+	_, err = os.Stat(filepath.Join(dir, "bar", "1.avsc"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestDirRegistry_CreateSchemaComparesDefaults(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	reg, _ := registry.NewDirRegistry(dir)
+	schema1 := `{"type":"record","name":"test","fields":[{"name":"a","type":"long","default":1}]}`
+	schema2 := `{"type":"record","name":"test","fields":[{"name":"a","type":"long","default":2}]}`
+
+	id1, _, err := reg.CreateSchema("foo", schema1)
+	assert.NoError(t, err)
+	id2, _, err := reg.CreateSchema("foo", schema2)
+	assert.NoError(t, err)
+	id3, _, err := reg.IsRegistered("foo", `{"name":"test", "type":"record", "fields":[{"name":"a","type":"long","default":1}]}`)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2, 1}, []int{id1, id2, id3})
+}
+
+func TestDirRegistry_CreateSchemaError(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	reg, _ := registry.NewDirRegistry(dir)
+
+	_, _, err := reg.CreateSchema("foo", `"invalid"`)
+
+	assert.Error(t, err)
+}
+
+func TestExport(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	_, _, _ = srv.Registry.CreateSchema("amount", `{"type":"fixed","name":"Amount","namespace":"com.acme","size":8}`)
+	refs := []registry.SchemaReference{{Name: "com.acme.Amount", Subject: "amount", Version: 1}}
+	_, _, _ = srv.Registry.CreateSchemaWithReferences("order", `{"type":"array","items":"com.acme.Amount"}`, refs)
+	_, _, _ = srv.Registry.CreateSchemaWithReferences("order", `["null",{"type":"array","items":"com.acme.Amount"}]`, refs)
+	_, _, _ = srv.Registry.CreateSchema("other", `{"type":"fixed","name":"Amount","namespace":"com.acme","size":8}`)
+	client, _ := registry.NewClient(srv.URL)
+
+	err := registry.Export(context.Background(), client, dir)
+
+	assert.NoError(t, err)
+	reg, err := registry.NewDirRegistry(dir)
+	assert.NoError(t, err)
+	subjects, _ := reg.GetSubjects()
+	assert.Equal(t, []string{"amount", "order", "other"}, subjects)
+	info, err := reg.GetLatestSchemaInfo("order")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, info.ID)
+	assert.Equal(t, 2, info.Version)
+	assert.Equal(t, refs, info.References)
+	assert.Equal(t, `["null",{"type":"array","items":"com.acme.Amount"}]`, info.Raw)
+	assert.Equal(t, `["null",{"type":"array","items":{"name":"com.acme.Amount","type":"fixed","size":8}}]`, info.Schema.String())
+	schema, err := reg.GetSchema(1)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"com.acme.Amount","type":"fixed","size":8}`, schema.String())
+	info, _ = reg.GetLatestSchemaInfo("other")
+	assert.Equal(t, 1, info.ID)
+}
+
+func TestExport_KeepsSchemaText(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	schema := `{"type":"record","name":"test","doc":"A test.","fields":[{"name":"a","type":"long","default":1}]}`
+	_, _, _ = srv.Registry.CreateSchema("foo", schema)
+	client, _ := registry.NewClient(srv.URL)
+
+	err := registry.Export(context.Background(), client, dir)
+
+	assert.NoError(t, err)
+	b, _ := ioutil.ReadFile(filepath.Join(dir, "foo", "1.avsc"))
+	assert.Equal(t, schema, string(b))
+}
+
+func TestExport_RegistryError(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	srv := registrytest.NewServer()
+	client, _ := registry.NewClient(srv.URL)
+	srv.Close()
+
+	err := registry.Export(context.Background(), client, dir)
+
+	assert.Error(t, err)
+}
+
+// tempDir creates a temporary directory, returning it with the func removing it.
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "registry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { _ = os.RemoveAll(dir) }
+}
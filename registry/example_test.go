@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/hamba/avro"
 	"github.com/hamba/avro/registry"
 )
 
@@ -32,3 +33,30 @@ func Example() {
 	fmt.Println("id: ", id)
 	fmt.Println("schema: ", schema)
 }
+
+func ExampleSerializer() {
+	reg, err := registry.NewClient("http://example.com")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	schema := `["null","string","int"]`
+	ser, err := registry.NewSerializer(reg, "foobar", schema)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	data, err := ser.Serialize(map[string]interface{}{"string": "foo"})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	des := registry.NewDeserializer(reg, avro.MustParse(schema))
+
+	var v map[string]interface{}
+	if err := des.Deserialize(data, &v); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("value: ", v)
+}
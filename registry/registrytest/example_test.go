@@ -0,0 +1,33 @@
+package registrytest_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hamba/avro/registry"
+	"github.com/hamba/avro/registry/registrytest"
+)
+
+func ExampleNewServer() {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+
+	client, err := registry.NewClient(srv.URL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	id, _, err := client.CreateSchema("foobar", `["null","string","int"]`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	schema, err := srv.Registry.GetSchema(id)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(id, schema)
+
+	// Output: 1 ["null","string","int"]
+}
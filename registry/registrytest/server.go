@@ -0,0 +1,49 @@
+/*
+Package registrytest provides an in memory schema registry and a fake Confluent Schema Registry server for testing.
+
+The Registry implements registry.Registry without any network access. The Server serves a Registry over the
+same REST endpoints used by registry.Client, so tests can exercise the real client and wire format.
+
+The Registry and its handler are provided by package server, so they can be used outside of tests.
+*/
+package registrytest
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/hamba/avro/registry/server"
+)
+
+// Registry is an in memory schema registry.
+type Registry = server.Registry
+
+// NewRegistry returns an empty in memory schema registry.
+func NewRegistry() *Registry {
+	return server.NewRegistry()
+}
+
+// NewHandler returns an http handler serving the registry REST endpoints.
+func NewHandler(reg *Registry) http.Handler {
+	return server.NewHandler(reg)
+}
+
+// Server is a fake schema registry server serving a Registry over HTTP.
+type Server struct {
+	*httptest.Server
+
+	// Registry is the registry being served.
+	Registry *Registry
+}
+
+// NewServer starts and returns a server serving an empty Registry.
+//
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	reg := NewRegistry()
+
+	return &Server{
+		Server:   httptest.NewServer(NewHandler(reg)),
+		Registry: reg,
+	}
+}
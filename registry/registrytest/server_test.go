@@ -0,0 +1,217 @@
+package registrytest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hamba/avro/registry"
+	"github.com/hamba/avro/registry/registrytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_CreateSchema(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	client, _ := registry.NewClient(srv.URL)
+
+	id, schema, err := client.CreateSchema("foobar", `["null","string","int"]`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+	assert.Equal(t, `["null","string","int"]`, schema.String())
+	got, err := srv.Registry.GetSchema(id)
+	assert.NoError(t, err)
+	assert.Equal(t, `["null","string","int"]`, got.String())
+}
+
+func TestServer_IsRegistered(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	_, _, _ = srv.Registry.CreateSchema("foobar", `"string"`)
+	client, _ := registry.NewClient(srv.URL)
+
+	id, _, err := client.IsRegistered("foobar", `"string"`)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	_, _, err = client.IsRegistered("foobar", `"int"`)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.(registry.Error).StatusCode)
+	assert.Equal(t, registry.CodeSchemaNotFound, err.(registry.Error).Code)
+}
+
+func TestServer_GetSchema(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	id, _, _ := srv.Registry.CreateSchema("foobar", `"string"`)
+	client, _ := registry.NewClient(srv.URL)
+
+	schema, err := client.GetSchema(id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `"string"`, schema.String())
+}
+
+func TestServer_GetSubjectsAndVersions(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	_, _, _ = srv.Registry.CreateSchema("foobar", `"int"`)
+	_, _, _ = srv.Registry.CreateSchema("foobar", `"long"`)
+	client, _ := registry.NewClient(srv.URL)
+
+	subjects, err := client.GetSubjects()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foobar"}, subjects)
+
+	versions, err := client.GetVersions("foobar")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+
+	schema, err := client.GetSchemaByVersion("foobar", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, `"int"`, schema.String())
+
+	info, err := client.GetLatestSchemaInfo("foobar")
+	assert.NoError(t, err)
+	assert.Equal(t, `"long"`, info.Schema.String())
+	assert.Equal(t, 2, info.ID)
+	assert.Equal(t, 2, info.Version)
+}
+
+func TestServer_IncompatibleSchema(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	client, _ := registry.NewClient(srv.URL)
+	_, _, _ = client.CreateSchema("foobar", `"long"`)
+
+	ok, err := client.TestCompatibility("foobar", registry.LatestVersion, `"int"`)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = client.CreateSchema("foobar", `"int"`)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusConflict, err.(registry.Error).StatusCode)
+}
+
+func TestServer_References(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	client, _ := registry.NewClient(srv.URL)
+	_, _, _ = client.CreateSchema("amount", `{"type":"fixed","name":"Amount","namespace":"com.acme","size":8}`)
+	refs := []registry.SchemaReference{{Name: "com.acme.Amount", Subject: "amount", Version: 1}}
+	id, _, err := client.CreateSchemaWithReferences("order", `{"type":"array","items":"com.acme.Amount"}`, refs)
+	assert.NoError(t, err)
+
+	schema, err := client.GetSchema(id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"array","items":{"name":"com.acme.Amount","type":"fixed","size":8}}`, schema.String())
+}
+
+func TestServer_Config(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	client, _ := registry.NewClient(srv.URL)
+
+	err := client.SetGlobalCompatibilityLevel(registry.Full)
+	assert.NoError(t, err)
+	lvl, err := client.GetGlobalCompatibilityLevel()
+	assert.NoError(t, err)
+	assert.Equal(t, registry.Full, lvl)
+
+	err = client.SetCompatibilityLevel("foobar", registry.None)
+	assert.NoError(t, err)
+	lvl, err = client.GetCompatibilityLevel("foobar")
+	assert.NoError(t, err)
+	assert.Equal(t, registry.None, lvl)
+}
+
+func TestServer_Mode(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	client, _ := registry.NewClient(srv.URL)
+
+	err := client.SetMode("foobar", registry.ReadOnly)
+	assert.NoError(t, err)
+	mode, err := client.GetMode("foobar")
+	assert.NoError(t, err)
+	assert.Equal(t, registry.ReadOnly, mode)
+
+	mode, err = client.GetGlobalMode()
+	assert.NoError(t, err)
+	assert.Equal(t, registry.ReadWrite, mode)
+
+	_, _, err = client.CreateSchema("foobar", `"string"`)
+	assert.Error(t, err)
+}
+
+func TestServer_Delete(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	client, _ := registry.NewClient(srv.URL)
+	id, _, _ := client.CreateSchema("foobar", `"int"`)
+	_, _, _ = client.CreateSchema("foobar", `"long"`)
+
+	svs, err := client.GetSchemaSubjectVersions(id)
+	assert.NoError(t, err)
+	assert.Equal(t, []registry.SubjectVersion{{Subject: "foobar", Version: 1}}, svs)
+
+	version, err := client.DeleteSchemaVersion("foobar", registry.LatestVersion, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, version)
+
+	versions, err := client.DeleteSubject("foobar", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, versions)
+
+	versions, err = client.DeleteSubject("foobar", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+}
+
+func TestServer_InvalidVersion(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	client, _ := registry.NewClient(srv.URL)
+
+	_, err := client.GetSchemaByVersion("foobar", 0)
+
+	assert.Error(t, err)
+	assert.Equal(t, registry.CodeInvalidVersion, err.(registry.Error).Code)
+}
+
+func TestServer_Serde(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	client, _ := registry.NewClient(srv.URL)
+	schema := `{"type":"record","name":"test","fields":[{"name":"a","type":"long"},{"name":"b","type":"string"}]}`
+	ser, err := registry.NewSerializer(client, "foobar-value", schema)
+	assert.NoError(t, err)
+
+	b, err := ser.Serialize(TestRecord{A: 27, B: "foo"})
+	assert.NoError(t, err)
+
+	var got TestRecord
+	err = registry.NewDeserializer(client, nil).Deserialize(b, &got)
+	assert.NoError(t, err)
+	assert.Equal(t, TestRecord{A: 27, B: "foo"}, got)
+}
+
+func TestServer_SerializerAddsFieldWithDefault(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Close()
+	client, _ := registry.NewClient(srv.URL)
+	v1 := `{"type":"record","name":"test","fields":[{"name":"a","type":"long"}]}`
+	v2 := `{"type":"record","name":"test","fields":[{"name":"a","type":"long"},{"name":"b","type":"string","default":"foo"}]}`
+	_, err := registry.NewSerializer(client, "foobar-value", v1)
+	assert.NoError(t, err)
+
+	_, err = registry.NewSerializer(client, "foobar-value", v2)
+
+	assert.NoError(t, err)
+}
+
+type TestRecord struct {
+	A int64  `avro:"a"`
+	B string `avro:"b"`
+}
@@ -0,0 +1,62 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hamba/avro"
+)
+
+// ReferenceFunc returns the schema and references of the subject version a schema reference points to.
+type ReferenceFunc func(ref SchemaReference) (string, []SchemaReference, error)
+
+// ParseWithReferences parses the schema into an isolated schema cache, after parsing the schemas
+// it references. The referenced schemas are looked up using fn.
+func ParseWithReferences(schema string, refs []SchemaReference, fn ReferenceFunc) (avro.Schema, error) {
+	cache := &avro.SchemaCache{}
+	if err := parseReferences(refs, fn, cache, map[SchemaReference]bool{}); err != nil {
+		return nil, err
+	}
+
+	return avro.ParseWithCache(schema, "", cache)
+}
+
+func parseReferences(refs []SchemaReference, fn ReferenceFunc, cache *avro.SchemaCache, seen map[SchemaReference]bool) error {
+	for _, ref := range refs {
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+
+		schema, refRefs, err := fn(ref)
+		if err != nil {
+			return err
+		}
+
+		// References must be parsed before the schemas that use them.
+		if err = parseReferences(refRefs, fn, cache, seen); err != nil {
+			return err
+		}
+
+		if _, err = avro.ParseWithCache(schema, "", cache); err != nil {
+			return fmt.Errorf("registry: unable to parse reference %s: %v", ref.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// SchemaKey returns a key identifying a schema and its references.
+//
+// The schema text is normalized, keeping the defaults and docs that the canonical
+// form drops, so schemas differing only in those have different keys.
+func SchemaKey(schema string, refs []SchemaReference) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(schema), &v); err == nil {
+		if b, err := json.Marshal(v); err == nil {
+			schema = string(b)
+		}
+	}
+
+	return schema + fmt.Sprint(refs)
+}
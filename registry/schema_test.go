@@ -0,0 +1,73 @@
+package registry_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hamba/avro"
+	"github.com/hamba/avro/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWithReferences(t *testing.T) {
+	schemas := map[string]string{
+		"amount":   `{"type":"fixed","name":"Amount","namespace":"com.acme","size":8}`,
+		"currency": `{"type":"record","name":"Money","namespace":"com.acme","fields":[{"name":"amount","type":"Amount"}]}`,
+	}
+	refs := map[string][]registry.SchemaReference{
+		"currency": {{Name: "com.acme.Amount", Subject: "amount", Version: 1}},
+	}
+	var lookups []string
+	fn := func(ref registry.SchemaReference) (string, []registry.SchemaReference, error) {
+		lookups = append(lookups, ref.Subject)
+		return schemas[ref.Subject], refs[ref.Subject], nil
+	}
+	schemaRefs := []registry.SchemaReference{
+		{Name: "com.acme.Money", Subject: "currency", Version: 1},
+		{Name: "com.acme.Amount", Subject: "amount", Version: 1},
+	}
+
+	schema, err := registry.ParseWithReferences(`{"type":"array","items":"com.acme.Money"}`, schemaRefs, fn)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	items := schema.(*avro.ArraySchema).Items().(*avro.RefSchema).Schema()
+	assert.Equal(t, `{"name":"com.acme.Money","type":"record","fields":[{"name":"amount","type":{"name":"com.acme.Amount","type":"fixed","size":8}}]}`, items.String())
+	assert.Equal(t, []string{"currency", "amount"}, lookups)
+}
+
+func TestParseWithReferences_LookupError(t *testing.T) {
+	fn := func(ref registry.SchemaReference) (string, []registry.SchemaReference, error) {
+		return "", nil, errors.New("test")
+	}
+	refs := []registry.SchemaReference{{Name: "com.acme.Amount", Subject: "amount", Version: 1}}
+
+	_, err := registry.ParseWithReferences(`{"type":"array","items":"com.acme.Amount"}`, refs, fn)
+
+	assert.EqualError(t, err, "test")
+}
+
+func TestParseWithReferences_InvalidReference(t *testing.T) {
+	fn := func(ref registry.SchemaReference) (string, []registry.SchemaReference, error) {
+		return `"invalid"`, nil, nil
+	}
+	refs := []registry.SchemaReference{{Name: "com.acme.Amount", Subject: "amount", Version: 1}}
+
+	_, err := registry.ParseWithReferences(`{"type":"array","items":"com.acme.Amount"}`, refs, fn)
+
+	assert.Error(t, err)
+}
+
+func TestSchemaKey(t *testing.T) {
+	refs := []registry.SchemaReference{{Name: "com.acme.Amount", Subject: "amount", Version: 1}}
+
+	key1 := registry.SchemaKey(`{"type":"record","name":"test","fields":[{"name":"a","type":"long","default":1}]}`, nil)
+	key2 := registry.SchemaKey(`{"name":"test", "type":"record", "fields":[{"name":"a","type":"long","default":1}]}`, nil)
+	key3 := registry.SchemaKey(`{"type":"record","name":"test","fields":[{"name":"a","type":"long","default":2}]}`, nil)
+	key4 := registry.SchemaKey(`{"type":"record","name":"test","fields":[{"name":"a","type":"long","default":1}]}`, refs)
+
+	assert.Equal(t, key1, key2)
+	assert.NotEqual(t, key1, key3)
+	assert.NotEqual(t, key1, key4)
+}
@@ -0,0 +1,137 @@
+package registry
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/hamba/avro"
+)
+
+// wireHeaderSize is the size of the Confluent wire format header: a magic
+// byte followed by the 4 byte big-endian schema id.
+const wireHeaderSize = 5
+
+const wireMagicByte = 0x0
+
+// Serializer encodes values in the Confluent wire format.
+type Serializer struct {
+	schema avro.Schema
+	id     int
+}
+
+// NewSerializer returns a serializer that encodes values with the given schema,
+// registering the schema under the subject in the registry.
+//
+// The schema is registered as given, not in its canonical form, so its defaults,
+// docs and aliases are kept for the compatibility checks of the registry.
+func NewSerializer(reg Registry, subject, schema string) (*Serializer, error) {
+	sch, err := avro.ParseWithCache(schema, "", &avro.SchemaCache{})
+	if err != nil {
+		return nil, err
+	}
+
+	return newSerializer(reg, subject, schema, sch)
+}
+
+func newSerializer(reg Registry, subject, raw string, schema avro.Schema) (*Serializer, error) {
+	id, _, err := reg.CreateSchema(subject, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Serializer{
+		schema: schema,
+		id:     id,
+	}, nil
+}
+
+// NewTopicSerializer returns a serializer that encodes the keys or values of messages on
+// a topic with the given schema, registering the schema under the subject derived by the
+// strategy. If strategy is nil, TopicNameStrategy is used.
+func NewTopicSerializer(reg Registry, topic string, isKey bool, schema string, strategy SubjectNameStrategy) (*Serializer, error) {
+	if strategy == nil {
+		strategy = TopicNameStrategy
+	}
+
+	sch, err := avro.ParseWithCache(schema, "", &avro.SchemaCache{})
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := strategy(topic, isKey, sch)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSerializer(reg, subject, schema, sch)
+}
+
+// ID returns the registry id of the serializer schema.
+func (s *Serializer) ID() int {
+	return s.id
+}
+
+// Serialize returns the Confluent wire format encoding of v.
+func (s *Serializer) Serialize(v interface{}) ([]byte, error) {
+	data, err := avro.Marshal(s.schema, v)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, wireHeaderSize, wireHeaderSize+len(data))
+	b[0] = wireMagicByte
+	binary.BigEndian.PutUint32(b[1:], uint32(s.id))
+
+	return append(b, data...), nil
+}
+
+// Deserializer decodes values in the Confluent wire format.
+type Deserializer struct {
+	reg    Registry
+	schema avro.Schema
+}
+
+// NewDeserializer returns a deserializer that decodes values, resolving the writer schema
+// from the registry into the given reader schema. If schema is nil, values are decoded
+// using the writer schema.
+func NewDeserializer(reg Registry, schema avro.Schema) *Deserializer {
+	return &Deserializer{
+		reg:    reg,
+		schema: schema,
+	}
+}
+
+// Deserialize parses the Confluent wire format data and stores the result in the value pointed to by v.
+func (d *Deserializer) Deserialize(data []byte, v interface{}) error {
+	return d.DeserializeContext(context.Background(), data, v)
+}
+
+// DeserializeContext parses the Confluent wire format data and stores the result in the value pointed to by v.
+func (d *Deserializer) DeserializeContext(ctx context.Context, data []byte, v interface{}) error {
+	id, payload, err := ParseWireHeader(data)
+	if err != nil {
+		return err
+	}
+
+	writer, err := getSchema(ctx, d.reg, id)
+	if err != nil {
+		return err
+	}
+
+	if d.schema == nil {
+		return avro.Unmarshal(writer, payload, v)
+	}
+
+	return avro.UnmarshalResolved(d.schema, writer, payload, v)
+}
+
+// ParseWireHeader returns the schema id and the Avro encoded payload of
+// the Confluent wire format data.
+func ParseWireHeader(data []byte) (int, []byte, error) {
+	if len(data) < wireHeaderSize || data[0] != wireMagicByte {
+		return 0, nil, errors.New("registry: invalid wire format header")
+	}
+
+	return int(binary.BigEndian.Uint32(data[1:wireHeaderSize])), data[wireHeaderSize:], nil
+}
@@ -0,0 +1,264 @@
+package registry_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hamba/avro"
+	"github.com/hamba/avro/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestRecord struct {
+	A int64  `avro:"a"`
+	B string `avro:"b"`
+}
+
+func TestNewSerializer(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/subjects/test/versions", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"id":10}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	schema := `"string"`
+
+	ser, err := registry.NewSerializer(client, "test", schema)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, ser.ID())
+}
+
+func TestNewSerializer_RegistryError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	schema := `"string"`
+
+	_, err := registry.NewSerializer(client, "test", schema)
+
+	assert.Error(t, err)
+}
+
+func TestNewTopicSerializer(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/subjects/orders-org.hamba.avro.test/versions", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"id":10}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	schema := `{"type":"record","name":"test","namespace":"org.hamba.avro","fields":[{"name":"a","type":"long"}]}`
+
+	ser, err := registry.NewTopicSerializer(client, "orders", false, schema, registry.TopicRecordNameStrategy)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, ser.ID())
+}
+
+func TestNewTopicSerializer_DefaultStrategy(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/subjects/orders-key/versions", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"id":10}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	schema := `"string"`
+
+	_, err := registry.NewTopicSerializer(client, "orders", true, schema, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestNewTopicSerializer_StrategyError(t *testing.T) {
+	client, _ := registry.NewClient("http://example.com")
+	schema := `"string"`
+
+	_, err := registry.NewTopicSerializer(client, "orders", false, schema, registry.RecordNameStrategy)
+
+	assert.Error(t, err)
+}
+
+func TestSerializer_Serialize(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":10}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	schema := `"string"`
+	ser, _ := registry.NewSerializer(client, "test", schema)
+
+	got, err := ser.Serialize("foo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x0, 0x0, 0x0, 0x0, 0xa, 0x06, 0x66, 0x6f, 0x6f}, got)
+}
+
+func TestNewSerializer_IsolatesSchemaNames(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":10}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	ser1, err := registry.NewSerializer(client, "foo", `{"type":"record","name":"org.hamba.avro.registry.Serde","fields":[{"name":"a","type":"long"}]}`)
+	assert.NoError(t, err)
+	ser2, err := registry.NewTopicSerializer(client, "bar", false, `{"type":"record","name":"org.hamba.avro.registry.Serde","fields":[{"name":"b","type":"string"}]}`, nil)
+	assert.NoError(t, err)
+
+	got1, err1 := ser1.Serialize(map[string]interface{}{"a": int64(27)})
+	got2, err2 := ser2.Serialize(map[string]interface{}{"b": "foo"})
+
+	assert.NoError(t, err1)
+	assert.Equal(t, []byte{0x0, 0x0, 0x0, 0x0, 0xa, 0x36}, got1)
+	assert.NoError(t, err2)
+	assert.Equal(t, []byte{0x0, 0x0, 0x0, 0x0, 0xa, 0x06, 0x66, 0x6f, 0x6f}, got2)
+	assert.Nil(t, avro.DefaultSchemaCache.Get("org.hamba.avro.registry.Serde"))
+}
+
+func TestSerializer_SerializeError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":10}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	schema := `"string"`
+	ser, _ := registry.NewSerializer(client, "test", schema)
+
+	_, err := ser.Serialize(1)
+
+	assert.Error(t, err)
+}
+
+func TestDeserializer_Deserialize(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/schemas/ids/10", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"schema":"\"string\""}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	des := registry.NewDeserializer(client, nil)
+
+	var got string
+	err := des.Deserialize([]byte{0x0, 0x0, 0x0, 0x0, 0xa, 0x06, 0x66, 0x6f, 0x6f}, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", got)
+}
+
+func TestDeserializer_DeserializeResolvesReaderSchema(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"test\",\"fields\":[{\"name\":\"c\",\"type\":\"int\"},{\"name\":\"a\",\"type\":\"int\"}]}"}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+	    {"name": "b", "type": "string", "default": "foo"}
+	]
+}`)
+	des := registry.NewDeserializer(client, reader)
+
+	var got TestRecord
+	err := des.Deserialize([]byte{0x0, 0x0, 0x0, 0x0, 0xa, 0x02, 0x36}, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestRecord{A: 27, B: "foo"}, got)
+}
+
+func TestDeserializer_DeserializeInvalidHeader(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "Short",
+			data: []byte{0x0, 0x0},
+		},
+		{
+			name: "Invalid Magic",
+			data: []byte{0x1, 0x0, 0x0, 0x0, 0xa, 0x02},
+		},
+	}
+
+	client, _ := registry.NewClient("http://example.com")
+	des := registry.NewDeserializer(client, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			err := des.Deserialize(tt.data, &got)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDeserializer_DeserializeRegistryError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	des := registry.NewDeserializer(client, nil)
+
+	var got string
+	err := des.Deserialize([]byte{0x0, 0x0, 0x0, 0x0, 0xa, 0x06, 0x66, 0x6f, 0x6f}, &got)
+
+	assert.Error(t, err)
+}
+
+func TestParseWireHeader(t *testing.T) {
+	id, payload, err := registry.ParseWireHeader([]byte{0x0, 0x0, 0x0, 0x1, 0x2, 0x36})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 258, id)
+	assert.Equal(t, []byte{0x36}, payload)
+}
+
+func TestDeserializer_DeserializeContext(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"schema":"\"string\""}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	des := registry.NewDeserializer(client, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var got string
+	err := des.DeserializeContext(ctx, []byte{0x0, 0x0, 0x0, 0x0, 0xa, 0x06, 0x66, 0x6f, 0x6f}, &got)
+
+	assert.Error(t, err)
+}
+
+func TestDeserializer_DeserializeContextWithoutContextRegistry(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"schema":"\"string\""}`))
+	}))
+	defer s.Close()
+	client, _ := registry.NewClient(s.URL)
+	des := registry.NewDeserializer(plainRegistry{client}, nil)
+
+	var got string
+	err := des.DeserializeContext(context.Background(), []byte{0x0, 0x0, 0x0, 0x0, 0xa, 0x06, 0x66, 0x6f, 0x6f}, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", got)
+}
+
+// plainRegistry hides the context aware methods of a registry.
+type plainRegistry struct {
+	registry.Registry
+}
@@ -0,0 +1,367 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hamba/avro/registry"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// NewHandler returns an http handler serving the registry REST endpoints.
+func NewHandler(reg *Registry) http.Handler {
+	return &handler{reg: reg}
+}
+
+type schemaRequest struct {
+	Schema     string                     `json:"schema"`
+	References []registry.SchemaReference `json:"references,omitempty"`
+}
+
+type schemaResponse struct {
+	Schema     string                     `json:"schema"`
+	References []registry.SchemaReference `json:"references,omitempty"`
+}
+
+type schemaInfoResponse struct {
+	Subject    string                     `json:"subject"`
+	ID         int                        `json:"id"`
+	Version    int                        `json:"version"`
+	Schema     string                     `json:"schema"`
+	References []registry.SchemaReference `json:"references,omitempty"`
+}
+
+type idResponse struct {
+	ID int `json:"id"`
+}
+
+type compatibilityResponse struct {
+	IsCompatible bool `json:"is_compatible"`
+}
+
+type configRequest struct {
+	Compatibility registry.CompatibilityLevel `json:"compatibility"`
+}
+
+type configResponse struct {
+	Level registry.CompatibilityLevel `json:"compatibilityLevel"`
+}
+
+type configUpdateResponse struct {
+	Compatibility registry.CompatibilityLevel `json:"compatibility"`
+}
+
+type modePayload struct {
+	Mode registry.Mode `json:"mode"`
+}
+
+type handler struct {
+	reg *Registry
+}
+
+// ServeHTTP serves the registry REST endpoints.
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	permanent := req.URL.Query().Get("permanent") == "true"
+
+	var (
+		out interface{}
+		err error
+	)
+	switch {
+	case match(parts, "schemas", "ids", "*") && req.Method == http.MethodGet:
+		out, err = h.getSchema(parts[2])
+
+	case match(parts, "schemas", "ids", "*", "versions") && req.Method == http.MethodGet:
+		out, err = h.getSchemaSubjectVersions(parts[2])
+
+	case match(parts, "subjects") && req.Method == http.MethodGet:
+		out, err = h.reg.GetSubjects()
+
+	case match(parts, "subjects", "*") && req.Method == http.MethodPost:
+		out, err = h.lookupSchema(req, parts[1])
+
+	case match(parts, "subjects", "*") && req.Method == http.MethodDelete:
+		out, err = h.reg.DeleteSubject(parts[1], permanent)
+
+	case match(parts, "subjects", "*", "versions") && req.Method == http.MethodGet:
+		out, err = h.reg.GetVersions(parts[1])
+
+	case match(parts, "subjects", "*", "versions") && req.Method == http.MethodPost:
+		out, err = h.createSchema(req, parts[1])
+
+	case match(parts, "subjects", "*", "versions", "*") && req.Method == http.MethodGet:
+		out, err = h.getVersion(parts[1], parts[3])
+
+	case match(parts, "subjects", "*", "versions", "*") && req.Method == http.MethodDelete:
+		out, err = h.deleteVersion(parts[1], parts[3], permanent)
+
+	case match(parts, "compatibility", "subjects", "*", "versions", "*") && req.Method == http.MethodPost:
+		out, err = h.testCompatibility(req, parts[2], parts[4])
+
+	case match(parts, "config") && req.Method == http.MethodGet:
+		out, err = h.getConfig("")
+
+	case match(parts, "config", "*") && req.Method == http.MethodGet:
+		out, err = h.getConfig(parts[1])
+
+	case match(parts, "config") && req.Method == http.MethodPut:
+		out, err = h.setConfig(req, "")
+
+	case match(parts, "config", "*") && req.Method == http.MethodPut:
+		out, err = h.setConfig(req, parts[1])
+
+	case match(parts, "mode") && req.Method == http.MethodGet:
+		out, err = h.getMode("")
+
+	case match(parts, "mode", "*") && req.Method == http.MethodGet:
+		out, err = h.getMode(parts[1])
+
+	case match(parts, "mode") && req.Method == http.MethodPut:
+		out, err = h.setMode(req, "")
+
+	case match(parts, "mode", "*") && req.Method == http.MethodPut:
+		out, err = h.setMode(req, parts[1])
+
+	default:
+		err = newError(http.StatusNotFound, http.StatusNotFound, "HTTP 404 Not Found")
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	_ = jsoniter.NewEncoder(w).Encode(out)
+}
+
+func (h *handler) getSchema(id string) (interface{}, error) {
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, newError(http.StatusNotFound, registry.CodeSchemaNotFound, "Schema not found")
+	}
+
+	h.reg.mu.Lock()
+	defer h.reg.mu.Unlock()
+
+	entry, ok := h.reg.schemas[i]
+	if !ok {
+		return nil, newError(http.StatusNotFound, registry.CodeSchemaNotFound, "Schema not found")
+	}
+
+	return schemaResponse{Schema: entry.schema, References: entry.refs}, nil
+}
+
+func (h *handler) getSchemaSubjectVersions(id string) (interface{}, error) {
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, newError(http.StatusNotFound, registry.CodeSchemaNotFound, "Schema not found")
+	}
+
+	return h.reg.GetSchemaSubjectVersions(i)
+}
+
+func (h *handler) lookupSchema(req *http.Request, subject string) (interface{}, error) {
+	var in schemaRequest
+	if err := decodeRequest(req, &in); err != nil {
+		return nil, err
+	}
+
+	info, err := h.reg.lookupSchema(req.Context(), subject, in.Schema, in.References)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSchemaInfoResponse(info), nil
+}
+
+func (h *handler) createSchema(req *http.Request, subject string) (interface{}, error) {
+	var in schemaRequest
+	if err := decodeRequest(req, &in); err != nil {
+		return nil, err
+	}
+
+	info, err := h.reg.createSchema(req.Context(), subject, in.Schema, in.References)
+	if err != nil {
+		return nil, err
+	}
+
+	return idResponse{ID: info.ID}, nil
+}
+
+func (h *handler) getVersion(subject, version string) (interface{}, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	h.reg.mu.Lock()
+	defer h.reg.mu.Unlock()
+
+	entry, err := h.reg.version(subject, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSchemaInfoResponse(h.reg.info(subject, entry)), nil
+}
+
+func (h *handler) deleteVersion(subject, version string, permanent bool) (interface{}, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.reg.DeleteSchemaVersion(subject, v, permanent)
+}
+
+func (h *handler) testCompatibility(req *http.Request, subject, version string) (interface{}, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	var in schemaRequest
+	if err = decodeRequest(req, &in); err != nil {
+		return nil, err
+	}
+
+	ok, err := h.reg.TestCompatibility(subject, v, in.Schema, in.References...)
+	if err != nil {
+		return nil, err
+	}
+
+	return compatibilityResponse{IsCompatible: ok}, nil
+}
+
+func (h *handler) getConfig(subject string) (interface{}, error) {
+	var (
+		lvl registry.CompatibilityLevel
+		err error
+	)
+	if subject == "" {
+		lvl, err = h.reg.GetGlobalCompatibilityLevel()
+	} else {
+		lvl, err = h.reg.GetCompatibilityLevel(subject)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return configResponse{Level: lvl}, nil
+}
+
+func (h *handler) setConfig(req *http.Request, subject string) (interface{}, error) {
+	var in configRequest
+	if err := decodeRequest(req, &in); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if subject == "" {
+		err = h.reg.SetGlobalCompatibilityLevel(in.Compatibility)
+	} else {
+		err = h.reg.SetCompatibilityLevel(subject, in.Compatibility)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return configUpdateResponse{Compatibility: in.Compatibility}, nil
+}
+
+func (h *handler) getMode(subject string) (interface{}, error) {
+	var (
+		mode registry.Mode
+		err  error
+	)
+	if subject == "" {
+		mode, err = h.reg.GetGlobalMode()
+	} else {
+		mode, err = h.reg.GetMode(subject)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return modePayload{Mode: mode}, nil
+}
+
+func (h *handler) setMode(req *http.Request, subject string) (interface{}, error) {
+	var in modePayload
+	if err := decodeRequest(req, &in); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if subject == "" {
+		err = h.reg.SetGlobalMode(in.Mode)
+	} else {
+		err = h.reg.SetMode(subject, in.Mode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+func newSchemaInfoResponse(info schemaInfo) schemaInfoResponse {
+	return schemaInfoResponse{
+		Subject:    info.Subject,
+		ID:         info.ID,
+		Version:    info.Version,
+		Schema:     info.Raw,
+		References: info.References,
+	}
+}
+
+// match determines if the path parts match the pattern, where "*" matches any part.
+func match(parts []string, pattern ...string) bool {
+	if len(parts) != len(pattern) {
+		return false
+	}
+
+	for i, p := range pattern {
+		if p != "*" && p != parts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func parseVersion(version string) (int, error) {
+	if version == "latest" {
+		return registry.LatestVersion, nil
+	}
+
+	v, err := strconv.Atoi(version)
+	if err != nil || v < 1 {
+		msg := "The specified version '" + version + "' is not a valid version id. " +
+			"Allowed values are between [1, 2^31-1] and the string \"latest\""
+		return 0, newError(http.StatusUnprocessableEntity, registry.CodeInvalidVersion, msg)
+	}
+
+	return v, nil
+}
+
+func decodeRequest(req *http.Request, v interface{}) error {
+	if err := jsoniter.NewDecoder(req.Body).Decode(v); err != nil {
+		return newError(http.StatusBadRequest, http.StatusBadRequest, "Unrecognized request body: "+err.Error())
+	}
+
+	return nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	regErr, ok := err.(registry.Error)
+	if !ok {
+		regErr = registry.Error{StatusCode: http.StatusInternalServerError, Code: registry.CodeBackendDataStore, Message: err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	w.WriteHeader(regErr.StatusCode)
+	_ = jsoniter.NewEncoder(w).Encode(regErr)
+}
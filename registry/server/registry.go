@@ -0,0 +1,717 @@
+/*
+Package server provides an in memory schema registry and an http handler serving it over the
+Confluent Schema Registry REST API.
+
+The Registry implements registry.Registry without any network access, and its state can be saved and
+loaded as JSON. NewHandler serves a Registry over the same REST endpoints used by registry.Client.
+*/
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/hamba/avro"
+	"github.com/hamba/avro/registry"
+)
+
+type schemaEntry struct {
+	schema string
+	refs   []registry.SchemaReference
+	parsed avro.Schema
+}
+
+type versionEntry struct {
+	version int
+	id      int
+	deleted bool
+}
+
+// Registry is an in memory schema registry.
+//
+// Compatibility of new schemas is enforced using registry.Compatible according to
+// the compatibility level of the subject. The global compatibility level defaults to Backward.
+type Registry struct {
+	mu sync.Mutex
+
+	ids      map[string]int // normalized schema and references to id
+	schemas  map[int]schemaEntry
+	nextID   int
+	subjects map[string][]*versionEntry
+
+	level  registry.CompatibilityLevel
+	levels map[string]registry.CompatibilityLevel
+	mode   registry.Mode
+	modes  map[string]registry.Mode
+}
+
+// NewRegistry returns an empty in memory schema registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		ids:      map[string]int{},
+		schemas:  map[int]schemaEntry{},
+		nextID:   1,
+		subjects: map[string][]*versionEntry{},
+		level:    registry.Backward,
+		levels:   map[string]registry.CompatibilityLevel{},
+		mode:     registry.ReadWrite,
+		modes:    map[string]registry.Mode{},
+	}
+}
+
+// GetSchema returns the schema with the given id.
+func (r *Registry) GetSchema(id int) (avro.Schema, error) {
+	return r.GetSchemaContext(context.Background(), id)
+}
+
+// GetSchemaContext returns the schema with the given id.
+func (r *Registry) GetSchemaContext(ctx context.Context, id int) (avro.Schema, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.schemas[id]
+	if !ok {
+		return nil, newError(http.StatusNotFound, registry.CodeSchemaNotFound, "Schema not found")
+	}
+
+	return entry.parsed, nil
+}
+
+// GetSubjects gets the registry subjects.
+func (r *Registry) GetSubjects() ([]string, error) {
+	return r.GetSubjectsContext(context.Background())
+}
+
+// GetSubjectsContext gets the registry subjects.
+func (r *Registry) GetSubjectsContext(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subjects := []string{}
+	for subject, versions := range r.subjects {
+		if len(liveVersions(versions)) > 0 {
+			subjects = append(subjects, subject)
+		}
+	}
+	sort.Strings(subjects)
+
+	return subjects, nil
+}
+
+// GetVersions gets the schema versions for a subject.
+func (r *Registry) GetVersions(subject string) ([]int, error) {
+	return r.GetVersionsContext(context.Background(), subject)
+}
+
+// GetVersionsContext gets the schema versions for a subject.
+func (r *Registry) GetVersionsContext(ctx context.Context, subject string) ([]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := liveVersions(r.subjects[subject])
+	if len(versions) == 0 {
+		return nil, errSubjectNotFound(subject)
+	}
+
+	nums := make([]int, len(versions))
+	for i, v := range versions {
+		nums[i] = v.version
+	}
+
+	return nums, nil
+}
+
+// GetSchemaByVersion gets the schema by version.
+func (r *Registry) GetSchemaByVersion(subject string, version int) (avro.Schema, error) {
+	return r.GetSchemaByVersionContext(context.Background(), subject, version)
+}
+
+// GetSchemaByVersionContext gets the schema by version.
+func (r *Registry) GetSchemaByVersionContext(ctx context.Context, subject string, version int) (avro.Schema, error) {
+	info, err := r.getSchemaInfo(ctx, subject, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return info.Schema, nil
+}
+
+// GetLatestSchema gets the latest schema for a subject.
+func (r *Registry) GetLatestSchema(subject string) (avro.Schema, error) {
+	return r.GetLatestSchemaContext(context.Background(), subject)
+}
+
+// GetLatestSchemaContext gets the latest schema for a subject.
+func (r *Registry) GetLatestSchemaContext(ctx context.Context, subject string) (avro.Schema, error) {
+	return r.GetSchemaByVersionContext(ctx, subject, registry.LatestVersion)
+}
+
+// GetLatestSchemaInfo gets the latest schema and schema metadata for a subject.
+func (r *Registry) GetLatestSchemaInfo(subject string) (registry.SchemaInfo, error) {
+	return r.GetLatestSchemaInfoContext(context.Background(), subject)
+}
+
+// GetLatestSchemaInfoContext gets the latest schema and schema metadata for a subject.
+func (r *Registry) GetLatestSchemaInfoContext(ctx context.Context, subject string) (registry.SchemaInfo, error) {
+	return r.GetSchemaInfoContext(ctx, subject, registry.LatestVersion)
+}
+
+// GetSchemaInfo gets the schema and schema metadata for a subject version.
+func (r *Registry) GetSchemaInfo(subject string, version int) (registry.SchemaInfo, error) {
+	return r.GetSchemaInfoContext(context.Background(), subject, version)
+}
+
+// GetSchemaInfoContext gets the schema and schema metadata for a subject version.
+func (r *Registry) GetSchemaInfoContext(ctx context.Context, subject string, version int) (registry.SchemaInfo, error) {
+	info, err := r.getSchemaInfo(ctx, subject, version)
+	if err != nil {
+		return registry.SchemaInfo{}, err
+	}
+
+	return info.SchemaInfo, nil
+}
+
+// CreateSchema creates a schema in the registry, returning the schema id.
+func (r *Registry) CreateSchema(subject string, schema string) (int, avro.Schema, error) {
+	return r.CreateSchemaWithReferencesContext(context.Background(), subject, schema, nil)
+}
+
+// CreateSchemaContext creates a schema in the registry, returning the schema id.
+func (r *Registry) CreateSchemaContext(ctx context.Context, subject string, schema string) (int, avro.Schema, error) {
+	return r.CreateSchemaWithReferencesContext(ctx, subject, schema, nil)
+}
+
+// CreateSchemaWithReferences creates a schema with references in the registry, returning the schema id.
+func (r *Registry) CreateSchemaWithReferences(subject string, schema string, refs []registry.SchemaReference) (int, avro.Schema, error) {
+	return r.CreateSchemaWithReferencesContext(context.Background(), subject, schema, refs)
+}
+
+// CreateSchemaWithReferencesContext creates a schema with references in the registry, returning the schema id.
+func (r *Registry) CreateSchemaWithReferencesContext(ctx context.Context, subject string, schema string, refs []registry.SchemaReference) (int, avro.Schema, error) {
+	info, err := r.createSchema(ctx, subject, schema, refs)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return info.ID, info.Schema, nil
+}
+
+// IsRegistered determines of the schema is registered.
+func (r *Registry) IsRegistered(subject string, schema string) (int, avro.Schema, error) {
+	return r.IsRegisteredWithReferencesContext(context.Background(), subject, schema, nil)
+}
+
+// IsRegisteredContext determines of the schema is registered.
+func (r *Registry) IsRegisteredContext(ctx context.Context, subject string, schema string) (int, avro.Schema, error) {
+	return r.IsRegisteredWithReferencesContext(ctx, subject, schema, nil)
+}
+
+// IsRegisteredWithReferences determines of the schema with references is registered.
+func (r *Registry) IsRegisteredWithReferences(subject string, schema string, refs []registry.SchemaReference) (int, avro.Schema, error) {
+	return r.IsRegisteredWithReferencesContext(context.Background(), subject, schema, refs)
+}
+
+// IsRegisteredWithReferencesContext determines of the schema with references is registered.
+func (r *Registry) IsRegisteredWithReferencesContext(ctx context.Context, subject string, schema string, refs []registry.SchemaReference) (int, avro.Schema, error) {
+	info, err := r.lookupSchema(ctx, subject, schema, refs)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return info.ID, info.Schema, nil
+}
+
+// TestCompatibility determines if the schema is compatible with the given version of a subject,
+// according to the compatibility level of the subject.
+func (r *Registry) TestCompatibility(subject string, version int, schema string, refs ...registry.SchemaReference) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	parsed, err := r.parse(schema, refs)
+	if err != nil {
+		return false, err
+	}
+
+	v, err := r.version(subject, version)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.checkCompatible(r.compatibilityLevel(subject), parsed, []*versionEntry{v})
+	return err == nil, nil
+}
+
+// GetGlobalCompatibilityLevel gets the global compatibility level.
+func (r *Registry) GetGlobalCompatibilityLevel() (registry.CompatibilityLevel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.level, nil
+}
+
+// SetGlobalCompatibilityLevel sets the global compatibility level.
+func (r *Registry) SetGlobalCompatibilityLevel(lvl registry.CompatibilityLevel) error {
+	if !validCompatibilityLevel(lvl) {
+		return errInvalidCompatibility(lvl)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.level = lvl
+	return nil
+}
+
+// GetCompatibilityLevel gets the compatibility level of a subject.
+func (r *Registry) GetCompatibilityLevel(subject string) (registry.CompatibilityLevel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lvl, ok := r.levels[subject]
+	if !ok {
+		msg := fmt.Sprintf("Subject '%s' does not have subject-level compatibility configured", subject)
+		return "", newError(http.StatusNotFound, registry.CodeSubjectLevelConfigNotSet, msg)
+	}
+
+	return lvl, nil
+}
+
+// SetCompatibilityLevel sets the compatibility level of a subject.
+func (r *Registry) SetCompatibilityLevel(subject string, lvl registry.CompatibilityLevel) error {
+	if !validCompatibilityLevel(lvl) {
+		return errInvalidCompatibility(lvl)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.levels[subject] = lvl
+	return nil
+}
+
+// DeleteSubject deletes a subject, returning the deleted versions.
+//
+// A subject must be soft deleted before it can be permanently deleted.
+func (r *Registry) DeleteSubject(subject string, permanent bool) ([]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkWritable(subject); err != nil {
+		return nil, err
+	}
+
+	versions, ok := r.subjects[subject]
+	if !ok {
+		return nil, errSubjectNotFound(subject)
+	}
+	live := liveVersions(versions)
+
+	if permanent {
+		if len(live) > 0 {
+			msg := fmt.Sprintf("Subject '%s' was not deleted first before being permanently deleted", subject)
+			return nil, newError(http.StatusNotFound, registry.CodeSubjectNotSoftDeleted, msg)
+		}
+
+		nums := make([]int, len(versions))
+		for i, v := range versions {
+			nums[i] = v.version
+		}
+		delete(r.subjects, subject)
+		return nums, nil
+	}
+
+	if len(live) == 0 {
+		msg := fmt.Sprintf("Subject '%s' was soft deleted", subject)
+		return nil, newError(http.StatusNotFound, registry.CodeSubjectSoftDeleted, msg)
+	}
+
+	nums := make([]int, len(live))
+	for i, v := range live {
+		v.deleted = true
+		nums[i] = v.version
+	}
+	return nums, nil
+}
+
+// DeleteSchemaVersion deletes a version of a subject, returning the deleted version.
+//
+// A version must be soft deleted before it can be permanently deleted.
+func (r *Registry) DeleteSchemaVersion(subject string, version int, permanent bool) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkWritable(subject); err != nil {
+		return 0, err
+	}
+
+	if !permanent {
+		v, err := r.version(subject, version)
+		if err != nil {
+			return 0, err
+		}
+
+		v.deleted = true
+		return v.version, nil
+	}
+
+	versions, ok := r.subjects[subject]
+	if !ok {
+		return 0, errSubjectNotFound(subject)
+	}
+	for i, v := range versions {
+		if v.version != version {
+			continue
+		}
+		if !v.deleted {
+			msg := fmt.Sprintf("Subject '%s' Version %d was not deleted first before being permanently deleted", subject, version)
+			return 0, newError(http.StatusNotFound, registry.CodeVersionNotSoftDeleted, msg)
+		}
+
+		r.subjects[subject] = append(versions[:i:i], versions[i+1:]...)
+		if len(r.subjects[subject]) == 0 {
+			delete(r.subjects, subject)
+		}
+		return version, nil
+	}
+
+	return 0, errVersionNotFound(version)
+}
+
+// GetSchemaSubjectVersions gets the subject versions using the schema with the given id.
+func (r *Registry) GetSchemaSubjectVersions(id int) ([]registry.SubjectVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.schemas[id]; !ok {
+		return nil, newError(http.StatusNotFound, registry.CodeSchemaNotFound, "Schema not found")
+	}
+
+	svs := []registry.SubjectVersion{}
+	for subject, versions := range r.subjects {
+		for _, v := range liveVersions(versions) {
+			if v.id == id {
+				svs = append(svs, registry.SubjectVersion{Subject: subject, Version: v.version})
+			}
+		}
+	}
+	sort.Slice(svs, func(i, j int) bool {
+		if svs[i].Subject != svs[j].Subject {
+			return svs[i].Subject < svs[j].Subject
+		}
+		return svs[i].Version < svs[j].Version
+	})
+
+	return svs, nil
+}
+
+// GetGlobalMode gets the global registry mode.
+func (r *Registry) GetGlobalMode() (registry.Mode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.mode, nil
+}
+
+// SetGlobalMode sets the global registry mode.
+func (r *Registry) SetGlobalMode(mode registry.Mode) error {
+	if !validMode(mode) {
+		return errInvalidMode(mode)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.mode = mode
+	return nil
+}
+
+// GetMode gets the registry mode of a subject.
+func (r *Registry) GetMode(subject string) (registry.Mode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mode, ok := r.modes[subject]
+	if !ok {
+		msg := fmt.Sprintf("Subject '%s' does not have subject-level mode configured", subject)
+		return "", newError(http.StatusNotFound, registry.CodeSubjectLevelModeNotSet, msg)
+	}
+
+	return mode, nil
+}
+
+// SetMode sets the registry mode of a subject.
+func (r *Registry) SetMode(subject string, mode registry.Mode) error {
+	if !validMode(mode) {
+		return errInvalidMode(mode)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.modes[subject] = mode
+	return nil
+}
+
+// schemaInfo is a registered subject version.
+type schemaInfo struct {
+	registry.SchemaInfo
+
+	Subject string
+}
+
+func (r *Registry) getSchemaInfo(ctx context.Context, subject string, version int) (schemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return schemaInfo{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, err := r.version(subject, version)
+	if err != nil {
+		return schemaInfo{}, err
+	}
+
+	return r.info(subject, v), nil
+}
+
+func (r *Registry) createSchema(ctx context.Context, subject, schema string, refs []registry.SchemaReference) (schemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return schemaInfo{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkWritable(subject); err != nil {
+		return schemaInfo{}, err
+	}
+
+	parsed, err := r.parse(schema, refs)
+	if err != nil {
+		return schemaInfo{}, err
+	}
+
+	key := registry.SchemaKey(schema, refs)
+	live := liveVersions(r.subjects[subject])
+	if id, ok := r.ids[key]; ok {
+		for _, v := range live {
+			if v.id == id {
+				return r.info(subject, v), nil
+			}
+		}
+	}
+
+	if err = r.checkCompatible(r.compatibilityLevel(subject), parsed, live); err != nil {
+		return schemaInfo{}, err
+	}
+
+	id, ok := r.ids[key]
+	if !ok {
+		id = r.nextID
+		r.nextID++
+		r.ids[key] = id
+		r.schemas[id] = schemaEntry{schema: schema, refs: refs, parsed: parsed}
+	}
+
+	versions := r.subjects[subject]
+	v := &versionEntry{version: 1, id: id}
+	if len(versions) > 0 {
+		v.version = versions[len(versions)-1].version + 1
+	}
+	r.subjects[subject] = append(versions, v)
+
+	return r.info(subject, v), nil
+}
+
+func (r *Registry) lookupSchema(ctx context.Context, subject, schema string, refs []registry.SchemaReference) (schemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return schemaInfo{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	live := liveVersions(r.subjects[subject])
+	if len(live) == 0 {
+		return schemaInfo{}, errSubjectNotFound(subject)
+	}
+
+	if _, err := r.parse(schema, refs); err != nil {
+		return schemaInfo{}, err
+	}
+
+	if id, ok := r.ids[registry.SchemaKey(schema, refs)]; ok {
+		for _, v := range live {
+			if v.id == id {
+				return r.info(subject, v), nil
+			}
+		}
+	}
+
+	return schemaInfo{}, newError(http.StatusNotFound, registry.CodeSchemaNotFound, "Schema not found")
+}
+
+func (r *Registry) info(subject string, v *versionEntry) schemaInfo {
+	entry := r.schemas[v.id]
+	return schemaInfo{
+		SchemaInfo: registry.SchemaInfo{
+			Schema:     entry.parsed,
+			ID:         v.id,
+			Version:    v.version,
+			References: entry.refs,
+			Raw:        entry.schema,
+		},
+		Subject: subject,
+	}
+}
+
+// version returns the live version of a subject. The mutex must be held.
+func (r *Registry) version(subject string, version int) (*versionEntry, error) {
+	versions, ok := r.subjects[subject]
+	if !ok {
+		return nil, errSubjectNotFound(subject)
+	}
+
+	live := liveVersions(versions)
+	if version == registry.LatestVersion {
+		if len(live) == 0 {
+			return nil, errVersionNotFound(version)
+		}
+		return live[len(live)-1], nil
+	}
+
+	for _, v := range live {
+		if v.version == version {
+			return v, nil
+		}
+	}
+
+	return nil, errVersionNotFound(version)
+}
+
+// parse parses the schema, after parsing the schemas it references. Invalid schemas
+// are returned as registry errors. The mutex must be held.
+func (r *Registry) parse(schema string, refs []registry.SchemaReference) (avro.Schema, error) {
+	parsed, err := registry.ParseWithReferences(schema, refs, func(ref registry.SchemaReference) (string, []registry.SchemaReference, error) {
+		v, err := r.version(ref.Subject, ref.Version)
+		if err != nil {
+			return "", nil, newError(http.StatusUnprocessableEntity, registry.CodeInvalidSchema, "Invalid schema reference "+ref.Name+": "+err.Error())
+		}
+		entry := r.schemas[v.id]
+
+		return entry.schema, entry.refs, nil
+	})
+	if err != nil {
+		if _, ok := err.(registry.Error); ok {
+			return nil, err
+		}
+		return nil, newError(http.StatusUnprocessableEntity, registry.CodeInvalidSchema, "Invalid schema: "+err.Error())
+	}
+
+	return parsed, nil
+}
+
+// checkCompatible checks the schema against the existing versions of a subject
+// according to the compatibility level. The mutex must be held.
+func (r *Registry) checkCompatible(lvl registry.CompatibilityLevel, schema avro.Schema, versions []*versionEntry) error {
+	history := make([]avro.Schema, len(versions))
+	for i, v := range versions {
+		history[i] = r.schemas[v.id].parsed
+	}
+
+	if err := registry.Compatible(lvl, schema, history...); err != nil {
+		if cerr, ok := err.(registry.CompatibilityError); ok {
+			err = cerr.Err
+		}
+		msg := "Schema being registered is incompatible with an earlier schema: " + err.Error()
+		return newError(http.StatusConflict, registry.CodeIncompatibleSchema, msg)
+	}
+
+	return nil
+}
+
+// compatibilityLevel returns the effective compatibility level of a subject. The mutex must be held.
+func (r *Registry) compatibilityLevel(subject string) registry.CompatibilityLevel {
+	if lvl, ok := r.levels[subject]; ok {
+		return lvl
+	}
+	return r.level
+}
+
+// checkWritable checks that the subject is not read only. The mutex must be held.
+func (r *Registry) checkWritable(subject string) error {
+	mode, ok := r.modes[subject]
+	if !ok {
+		mode = r.mode
+	}
+
+	if mode == registry.ReadOnly {
+		msg := fmt.Sprintf("Subject '%s' is in read-only mode", subject)
+		return newError(http.StatusUnprocessableEntity, registry.CodeOperationNotPermitted, msg)
+	}
+	return nil
+}
+
+func liveVersions(versions []*versionEntry) []*versionEntry {
+	live := make([]*versionEntry, 0, len(versions))
+	for _, v := range versions {
+		if !v.deleted {
+			live = append(live, v)
+		}
+	}
+	return live
+}
+
+func validCompatibilityLevel(lvl registry.CompatibilityLevel) bool {
+	switch lvl {
+	case registry.None, registry.Backward, registry.BackwardTransitive, registry.Forward,
+		registry.ForwardTransitive, registry.Full, registry.FullTransitive:
+		return true
+	}
+	return false
+}
+
+func validMode(mode registry.Mode) bool {
+	switch mode {
+	case registry.ReadWrite, registry.ReadOnly, registry.Import:
+		return true
+	}
+	return false
+}
+
+func newError(status, code int, msg string) error {
+	return registry.Error{StatusCode: status, Code: code, Message: msg}
+}
+
+func errSubjectNotFound(subject string) error {
+	return newError(http.StatusNotFound, registry.CodeSubjectNotFound, fmt.Sprintf("Subject '%s' not found.", subject))
+}
+
+func errVersionNotFound(version int) error {
+	return newError(http.StatusNotFound, registry.CodeVersionNotFound, fmt.Sprintf("Version %d not found.", version))
+}
+
+func errInvalidCompatibility(lvl registry.CompatibilityLevel) error {
+	msg := fmt.Sprintf("Invalid compatibility level: %s", lvl)
+	return newError(http.StatusUnprocessableEntity, registry.CodeInvalidCompatibility, msg)
+}
+
+func errInvalidMode(mode registry.Mode) error {
+	return newError(http.StatusUnprocessableEntity, registry.CodeInvalidMode, fmt.Sprintf("Invalid mode: %s", mode))
+}
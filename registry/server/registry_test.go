@@ -0,0 +1,384 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hamba/avro/registry"
+	"github.com/hamba/avro/registry/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_ImplementsRegistry(t *testing.T) {
+	var reg registry.ContextRegistry = server.NewRegistry()
+	var refReg registry.ReferenceRegistry = server.NewRegistry()
+
+	assert.NotNil(t, reg)
+	assert.NotNil(t, refReg)
+}
+
+func TestRegistry_CreateSchema(t *testing.T) {
+	reg := server.NewRegistry()
+
+	id, schema, err := reg.CreateSchema("foobar", `["null","string","int"]`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+	assert.Equal(t, `["null","string","int"]`, schema.String())
+}
+
+func TestRegistry_CreateSchemaIsIdempotent(t *testing.T) {
+	reg := server.NewRegistry()
+	id1, _, _ := reg.CreateSchema("foobar", `["null","string","int"]`)
+
+	id2, _, err := reg.CreateSchema("foobar", `["null", "string", "int"]`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, id1, id2)
+	versions, _ := reg.GetVersions("foobar")
+	assert.Equal(t, []int{1}, versions)
+}
+
+func TestRegistry_CreateSchemaDistinguishesDefaults(t *testing.T) {
+	reg := server.NewRegistry()
+	id1, _, _ := reg.CreateSchema("foobar", `{"type":"record","name":"test","fields":[{"name":"a","type":"long","default":1}]}`)
+
+	id2, _, err := reg.CreateSchema("foobar", `{"type":"record","name":"test","fields":[{"name":"a","type":"long","default":2}]}`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, []int{id1, id2})
+	versions, _ := reg.GetVersions("foobar")
+	assert.Equal(t, []int{1, 2}, versions)
+}
+
+func TestRegistry_CreateSchemaSharesIDsAcrossSubjects(t *testing.T) {
+	reg := server.NewRegistry()
+	id1, _, _ := reg.CreateSchema("foo", `"string"`)
+
+	id2, _, err := reg.CreateSchema("bar", `"string"`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, id1, id2)
+	svs, _ := reg.GetSchemaSubjectVersions(id1)
+	assert.Equal(t, []registry.SubjectVersion{{Subject: "bar", Version: 1}, {Subject: "foo", Version: 1}}, svs)
+}
+
+func TestRegistry_CreateSchemaInvalidSchema(t *testing.T) {
+	reg := server.NewRegistry()
+
+	_, _, err := reg.CreateSchema("foobar", `["null","string","int"`)
+
+	assert.Error(t, err)
+	assert.Equal(t, registry.CodeInvalidSchema, err.(registry.Error).Code)
+}
+
+func TestRegistry_CreateSchemaCompatibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   registry.CompatibilityLevel
+		schemas []string
+		schema  string
+		wantErr bool
+	}{
+		{
+			name:    "None",
+			level:   registry.None,
+			schemas: []string{`"string"`},
+			schema:  `"int"`,
+			wantErr: false,
+		},
+		{
+			name:    "Backward",
+			level:   registry.Backward,
+			schemas: []string{`"int"`},
+			schema:  `"long"`,
+			wantErr: false,
+		},
+		{
+			name:    "Backward Incompatible",
+			level:   registry.Backward,
+			schemas: []string{`"long"`},
+			schema:  `"int"`,
+			wantErr: true,
+		},
+		{
+			name:    "Backward Only Latest",
+			level:   registry.Backward,
+			schemas: []string{`"string"`, `"int"`},
+			schema:  `"long"`,
+			wantErr: false,
+		},
+		{
+			name:    "Backward Transitive",
+			level:   registry.BackwardTransitive,
+			schemas: []string{`"string"`, `"int"`},
+			schema:  `"long"`,
+			wantErr: true,
+		},
+		{
+			name:    "Forward",
+			level:   registry.Forward,
+			schemas: []string{`"long"`},
+			schema:  `"int"`,
+			wantErr: false,
+		},
+		{
+			name:    "Forward Incompatible",
+			level:   registry.Forward,
+			schemas: []string{`"int"`},
+			schema:  `"long"`,
+			wantErr: true,
+		},
+		{
+			name:    "Full Incompatible",
+			level:   registry.Full,
+			schemas: []string{`"long"`},
+			schema:  `"int"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := server.NewRegistry()
+			_ = reg.SetCompatibilityLevel("foobar", registry.None)
+			for _, s := range tt.schemas {
+				_, _, err := reg.CreateSchema("foobar", s)
+				assert.NoError(t, err)
+			}
+			_ = reg.SetCompatibilityLevel("foobar", tt.level)
+
+			_, _, err := reg.CreateSchema("foobar", tt.schema)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, registry.CodeIncompatibleSchema, err.(registry.Error).Code)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRegistry_CreateSchemaReadOnly(t *testing.T) {
+	reg := server.NewRegistry()
+	_ = reg.SetMode("foobar", registry.ReadOnly)
+
+	_, _, err := reg.CreateSchema("foobar", `"string"`)
+
+	assert.Error(t, err)
+	assert.Equal(t, registry.CodeOperationNotPermitted, err.(registry.Error).Code)
+}
+
+func TestRegistry_CreateSchemaWithReferences(t *testing.T) {
+	reg := server.NewRegistry()
+	_, _, _ = reg.CreateSchema("amount", `{"type":"fixed","name":"Amount","namespace":"com.acme","size":8}`)
+	refs := []registry.SchemaReference{{Name: "com.acme.Amount", Subject: "amount", Version: 1}}
+
+	id, schema, err := reg.CreateSchemaWithReferences("order", `{"type":"array","items":"com.acme.Amount"}`, refs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, id)
+	assert.Equal(t, `{"type":"array","items":{"name":"com.acme.Amount","type":"fixed","size":8}}`, schema.String())
+}
+
+func TestRegistry_CreateSchemaContextCancelled(t *testing.T) {
+	reg := server.NewRegistry()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := reg.CreateSchemaContext(ctx, "foobar", `"string"`)
+
+	assert.Error(t, err)
+}
+
+func TestRegistry_IsRegistered(t *testing.T) {
+	reg := server.NewRegistry()
+	_, _, _ = reg.CreateSchema("foobar", `"string"`)
+	_, _, _ = reg.CreateSchema("foobar", `["null","string"]`)
+
+	id, schema, err := reg.IsRegistered("foobar", `"string"`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+	assert.Equal(t, `"string"`, schema.String())
+}
+
+func TestRegistry_IsRegisteredNotFound(t *testing.T) {
+	reg := server.NewRegistry()
+	_, _, _ = reg.CreateSchema("foobar", `"string"`)
+
+	_, _, err := reg.IsRegistered("foobar", `"int"`)
+
+	assert.Error(t, err)
+	assert.Equal(t, registry.CodeSchemaNotFound, err.(registry.Error).Code)
+}
+
+func TestRegistry_GetSchema(t *testing.T) {
+	reg := server.NewRegistry()
+	id, _, _ := reg.CreateSchema("foobar", `"string"`)
+
+	schema, err := reg.GetSchema(id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `"string"`, schema.String())
+}
+
+func TestRegistry_GetSchemaNotFound(t *testing.T) {
+	reg := server.NewRegistry()
+
+	_, err := reg.GetSchema(1)
+
+	assert.Error(t, err)
+	assert.Equal(t, registry.CodeSchemaNotFound, err.(registry.Error).Code)
+}
+
+func TestRegistry_GetSubjects(t *testing.T) {
+	reg := server.NewRegistry()
+	_, _, _ = reg.CreateSchema("foo", `"string"`)
+	_, _, _ = reg.CreateSchema("bar", `"string"`)
+
+	subjects, err := reg.GetSubjects()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bar", "foo"}, subjects)
+}
+
+func TestRegistry_GetVersionsSubjectNotFound(t *testing.T) {
+	reg := server.NewRegistry()
+
+	_, err := reg.GetVersions("foobar")
+
+	assert.Error(t, err)
+	assert.Equal(t, registry.CodeSubjectNotFound, err.(registry.Error).Code)
+}
+
+func TestRegistry_GetSchemaByVersion(t *testing.T) {
+	reg := server.NewRegistry()
+	_, _, _ = reg.CreateSchema("foobar", `"string"`)
+	_, _, _ = reg.CreateSchema("foobar", `["null","string"]`)
+
+	schema, err := reg.GetSchemaByVersion("foobar", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `"string"`, schema.String())
+}
+
+func TestRegistry_GetSchemaByVersionNotFound(t *testing.T) {
+	reg := server.NewRegistry()
+	_, _, _ = reg.CreateSchema("foobar", `"string"`)
+
+	_, err := reg.GetSchemaByVersion("foobar", 2)
+
+	assert.Error(t, err)
+	assert.Equal(t, registry.CodeVersionNotFound, err.(registry.Error).Code)
+}
+
+func TestRegistry_GetLatestSchemaInfo(t *testing.T) {
+	reg := server.NewRegistry()
+	_, _, _ = reg.CreateSchema("foobar", `"string"`)
+	_, _, _ = reg.CreateSchema("foobar", `["null","string"]`)
+
+	info, err := reg.GetLatestSchemaInfo("foobar")
+
+	assert.NoError(t, err)
+	assert.Equal(t, `["null","string"]`, info.Schema.String())
+	assert.Equal(t, 2, info.ID)
+	assert.Equal(t, 2, info.Version)
+}
+
+func TestRegistry_TestCompatibility(t *testing.T) {
+	reg := server.NewRegistry()
+	_, _, _ = reg.CreateSchema("foobar", `"int"`)
+
+	ok, err := reg.TestCompatibility("foobar", registry.LatestVersion, `"long"`)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = reg.TestCompatibility("foobar", registry.LatestVersion, `"string"`)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRegistry_CompatibilityLevel(t *testing.T) {
+	reg := server.NewRegistry()
+
+	lvl, err := reg.GetGlobalCompatibilityLevel()
+	assert.NoError(t, err)
+	assert.Equal(t, registry.Backward, lvl)
+
+	_, err = reg.GetCompatibilityLevel("foobar")
+	assert.Error(t, err)
+
+	err = reg.SetCompatibilityLevel("foobar", registry.Full)
+	assert.NoError(t, err)
+	lvl, err = reg.GetCompatibilityLevel("foobar")
+	assert.NoError(t, err)
+	assert.Equal(t, registry.Full, lvl)
+
+	err = reg.SetGlobalCompatibilityLevel("foo")
+	assert.Error(t, err)
+}
+
+func TestRegistry_DeleteSubject(t *testing.T) {
+	reg := server.NewRegistry()
+	_, _, _ = reg.CreateSchema("foobar", `"string"`)
+	_, _, _ = reg.CreateSchema("foobar", `["null","string"]`)
+
+	_, err := reg.DeleteSubject("foobar", true)
+	assert.Error(t, err)
+
+	versions, err := reg.DeleteSubject("foobar", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+	_, err = reg.GetVersions("foobar")
+	assert.Error(t, err)
+
+	versions, err = reg.DeleteSubject("foobar", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+	_, err = reg.DeleteSubject("foobar", false)
+	assert.Error(t, err)
+}
+
+func TestRegistry_DeleteSchemaVersion(t *testing.T) {
+	reg := server.NewRegistry()
+	_, _, _ = reg.CreateSchema("foobar", `"string"`)
+	_, _, _ = reg.CreateSchema("foobar", `["null","string"]`)
+
+	_, err := reg.DeleteSchemaVersion("foobar", 2, true)
+	assert.Error(t, err)
+
+	version, err := reg.DeleteSchemaVersion("foobar", registry.LatestVersion, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, version)
+	schema, _ := reg.GetLatestSchema("foobar")
+	assert.Equal(t, `"string"`, schema.String())
+
+	version, err = reg.DeleteSchemaVersion("foobar", 2, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, version)
+	_, _, _ = reg.CreateSchema("foobar", `["null","string"]`)
+	versions, _ := reg.GetVersions("foobar")
+	assert.Equal(t, []int{1, 2}, versions)
+}
+
+func TestRegistry_Mode(t *testing.T) {
+	reg := server.NewRegistry()
+
+	mode, err := reg.GetGlobalMode()
+	assert.NoError(t, err)
+	assert.Equal(t, registry.ReadWrite, mode)
+
+	_, err = reg.GetMode("foobar")
+	assert.Error(t, err)
+
+	err = reg.SetMode("foobar", registry.ReadOnly)
+	assert.NoError(t, err)
+	mode, err = reg.GetMode("foobar")
+	assert.NoError(t, err)
+	assert.Equal(t, registry.ReadOnly, mode)
+
+	err = reg.SetGlobalMode("foo")
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"io"
+	"sort"
+
+	"github.com/hamba/avro/registry"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type snapshot struct {
+	Compatibility registry.CompatibilityLevel `json:"compatibility"`
+	Mode          registry.Mode               `json:"mode"`
+	Schemas       []snapshotSchema            `json:"schemas"`
+	Subjects      []snapshotSubject           `json:"subjects"`
+}
+
+type snapshotSchema struct {
+	ID         int                        `json:"id"`
+	Schema     string                     `json:"schema"`
+	References []registry.SchemaReference `json:"references,omitempty"`
+}
+
+type snapshotSubject struct {
+	Subject       string                      `json:"subject"`
+	Compatibility registry.CompatibilityLevel `json:"compatibility,omitempty"`
+	Mode          registry.Mode               `json:"mode,omitempty"`
+	Versions      []snapshotVersion           `json:"versions"`
+}
+
+type snapshotVersion struct {
+	Version int  `json:"version"`
+	ID      int  `json:"id"`
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// Save writes the state of the registry to w as JSON.
+func (r *Registry) Save(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snap := snapshot{
+		Compatibility: r.level,
+		Mode:          r.mode,
+		Schemas:       make([]snapshotSchema, 0, len(r.schemas)),
+	}
+	for id, entry := range r.schemas {
+		snap.Schemas = append(snap.Schemas, snapshotSchema{ID: id, Schema: entry.schema, References: entry.refs})
+	}
+	sort.Slice(snap.Schemas, func(i, j int) bool {
+		return snap.Schemas[i].ID < snap.Schemas[j].ID
+	})
+
+	subjects := map[string]bool{}
+	for subject := range r.subjects {
+		subjects[subject] = true
+	}
+	for subject := range r.levels {
+		subjects[subject] = true
+	}
+	for subject := range r.modes {
+		subjects[subject] = true
+	}
+	for subject := range subjects {
+		s := snapshotSubject{
+			Subject:       subject,
+			Compatibility: r.levels[subject],
+			Mode:          r.modes[subject],
+			Versions:      []snapshotVersion{},
+		}
+		for _, v := range r.subjects[subject] {
+			s.Versions = append(s.Versions, snapshotVersion{Version: v.version, ID: v.id, Deleted: v.deleted})
+		}
+		snap.Subjects = append(snap.Subjects, s)
+	}
+	sort.Slice(snap.Subjects, func(i, j int) bool {
+		return snap.Subjects[i].Subject < snap.Subjects[j].Subject
+	})
+
+	enc := jsoniter.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snap)
+}
+
+// Load replaces the state of the registry with the JSON state read from rd,
+// as written by Save.
+func (r *Registry) Load(rd io.Reader) error {
+	var snap snapshot
+	if err := jsoniter.NewDecoder(rd).Decode(&snap); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loaded := NewRegistry()
+	if snap.Compatibility != "" {
+		loaded.level = snap.Compatibility
+	}
+	if snap.Mode != "" {
+		loaded.mode = snap.Mode
+	}
+	for _, s := range snap.Schemas {
+		loaded.schemas[s.ID] = schemaEntry{schema: s.Schema, refs: s.References}
+		if s.ID >= loaded.nextID {
+			loaded.nextID = s.ID + 1
+		}
+	}
+	for _, s := range snap.Subjects {
+		if s.Compatibility != "" {
+			loaded.levels[s.Subject] = s.Compatibility
+		}
+		if s.Mode != "" {
+			loaded.modes[s.Subject] = s.Mode
+		}
+		for _, v := range s.Versions {
+			loaded.subjects[s.Subject] = append(loaded.subjects[s.Subject], &versionEntry{version: v.Version, id: v.ID, deleted: v.Deleted})
+		}
+	}
+
+	// Schemas are parsed once all schemas are known, so references can be resolved.
+	for id, entry := range loaded.schemas {
+		parsed, err := loaded.parse(entry.schema, entry.refs)
+		if err != nil {
+			return err
+		}
+
+		entry.parsed = parsed
+		loaded.schemas[id] = entry
+		loaded.ids[registry.SchemaKey(entry.schema, entry.refs)] = id
+	}
+
+	r.ids = loaded.ids
+	r.schemas = loaded.schemas
+	r.nextID = loaded.nextID
+	r.subjects = loaded.subjects
+	r.level = loaded.level
+	r.levels = loaded.levels
+	r.mode = loaded.mode
+	r.modes = loaded.modes
+
+	return nil
+}
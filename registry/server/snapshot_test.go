@@ -0,0 +1,105 @@
+package server_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hamba/avro/registry"
+	"github.com/hamba/avro/registry/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_SaveLoad(t *testing.T) {
+	reg := server.NewRegistry()
+	_ = reg.SetGlobalCompatibilityLevel(registry.Full)
+	_ = reg.SetCompatibilityLevel("amount", registry.None)
+	_ = reg.SetMode("readonly", registry.ReadOnly)
+	_, _, _ = reg.CreateSchema("amount", `{"type":"fixed","name":"Amount","namespace":"com.acme","size":8}`)
+	_, _, _ = reg.CreateSchema("amount", `"string"`)
+	_, _ = reg.DeleteSchemaVersion("amount", 2, false)
+	refs := []registry.SchemaReference{{Name: "com.acme.Amount", Subject: "amount", Version: 1}}
+	_, _, _ = reg.CreateSchemaWithReferences("order", `{"type":"array","items":"com.acme.Amount"}`, refs)
+	buf := &bytes.Buffer{}
+
+	err := reg.Save(buf)
+	assert.NoError(t, err)
+
+	got := server.NewRegistry()
+	err = got.Load(buf)
+	assert.NoError(t, err)
+
+	lvl, _ := got.GetGlobalCompatibilityLevel()
+	assert.Equal(t, registry.Full, lvl)
+	lvl, _ = got.GetCompatibilityLevel("amount")
+	assert.Equal(t, registry.None, lvl)
+	mode, _ := got.GetMode("readonly")
+	assert.Equal(t, registry.ReadOnly, mode)
+	versions, _ := got.GetVersions("amount")
+	assert.Equal(t, []int{1}, versions)
+	info, err := got.GetLatestSchemaInfo("order")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, info.ID)
+	assert.Equal(t, refs, info.References)
+	assert.Equal(t, `{"type":"array","items":{"name":"com.acme.Amount","type":"fixed","size":8}}`, info.Schema.String())
+	id, _, err := got.IsRegisteredWithReferences("order", `{"type":"array","items":"com.acme.Amount"}`, refs)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, id)
+	id, _, err = got.CreateSchema("other", `"int"`)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, id)
+}
+
+func TestRegistry_LoadNonContiguousIDs(t *testing.T) {
+	data := `{"schemas":[{"id":2,"schema":"\"string\""},{"id":5,"schema":"\"int\""}],` +
+		`"subjects":[{"subject":"foo","versions":[{"version":1,"id":2},{"version":2,"id":5}]}]}`
+	reg := server.NewRegistry()
+
+	err := reg.Load(bytes.NewBufferString(data))
+
+	assert.NoError(t, err)
+	_ = reg.SetCompatibilityLevel("foo", registry.None)
+	id, _, err := reg.CreateSchema("foo", `"long"`)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, id)
+	schema, _ := reg.GetSchema(5)
+	assert.Equal(t, `"int"`, schema.String())
+	buf := &bytes.Buffer{}
+	_ = reg.Save(buf)
+	got := server.NewRegistry()
+	err = got.Load(buf)
+	assert.NoError(t, err)
+	for id, want := range map[int]string{2: `"string"`, 5: `"int"`, 6: `"long"`} {
+		schema, err := got.GetSchema(id)
+		if assert.NoError(t, err) {
+			assert.Equal(t, want, schema.String())
+		}
+	}
+	_, err = got.GetSchema(1)
+	assert.Error(t, err)
+}
+
+func TestRegistry_LoadError(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "Invalid JSON",
+			data: `{`,
+		},
+		{
+			name: "Invalid Schema",
+			data: `{"schemas":[{"id":1,"schema":"{"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := server.NewRegistry()
+
+			err := reg.Load(bytes.NewBufferString(tt.data))
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/hamba/avro"
+)
+
+// SubjectNameStrategy derives the registry subject of a schema used to encode the
+// key or value of messages on a topic.
+type SubjectNameStrategy func(topic string, isKey bool, schema avro.Schema) (string, error)
+
+// TopicNameStrategy derives the subject from the topic, as "<topic>-key" or "<topic>-value".
+//
+// This is the default strategy of the Confluent serializers.
+func TopicNameStrategy(topic string, isKey bool, _ avro.Schema) (string, error) {
+	if isKey {
+		return topic + "-key", nil
+	}
+	return topic + "-value", nil
+}
+
+// RecordNameStrategy derives the subject from the full name of the schema, allowing
+// several schemas on one topic.
+func RecordNameStrategy(_ string, _ bool, schema avro.Schema) (string, error) {
+	return fullName(schema)
+}
+
+// TopicRecordNameStrategy derives the subject from the topic and the full name of
+// the schema, as "<topic>-<full name>".
+func TopicRecordNameStrategy(topic string, _ bool, schema avro.Schema) (string, error) {
+	name, err := fullName(schema)
+	if err != nil {
+		return "", err
+	}
+
+	return topic + "-" + name, nil
+}
+
+func fullName(schema avro.Schema) (string, error) {
+	named, ok := schema.(avro.NamedSchema)
+	if !ok {
+		return "", fmt.Errorf("registry: subject name strategy requires a named schema, got %s", schema.Type())
+	}
+
+	return named.FullName(), nil
+}
@@ -0,0 +1,82 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/hamba/avro"
+	"github.com/hamba/avro/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubjectNameStrategy(t *testing.T) {
+	record := `{"type":"record","name":"test","namespace":"org.hamba.avro","fields":[{"name":"a","type":"long"}]}`
+
+	tests := []struct {
+		name     string
+		strategy registry.SubjectNameStrategy
+		isKey    bool
+		schema   string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "Topic Name Value",
+			strategy: registry.TopicNameStrategy,
+			schema:   `"string"`,
+			want:     "orders-value",
+		},
+		{
+			name:     "Topic Name Key",
+			strategy: registry.TopicNameStrategy,
+			isKey:    true,
+			schema:   `"string"`,
+			want:     "orders-key",
+		},
+		{
+			name:     "Record Name",
+			strategy: registry.RecordNameStrategy,
+			schema:   record,
+			want:     "org.hamba.avro.test",
+		},
+		{
+			name:     "Record Name Enum",
+			strategy: registry.RecordNameStrategy,
+			schema:   `{"type":"enum","name":"test","namespace":"org.hamba.avro","symbols":["foo"]}`,
+			want:     "org.hamba.avro.test",
+		},
+		{
+			name:     "Record Name Unnamed",
+			strategy: registry.RecordNameStrategy,
+			schema:   `"string"`,
+			wantErr:  true,
+		},
+		{
+			name:     "Topic Record Name",
+			strategy: registry.TopicRecordNameStrategy,
+			isKey:    true,
+			schema:   record,
+			want:     "orders-org.hamba.avro.test",
+		},
+		{
+			name:     "Topic Record Name Unnamed",
+			strategy: registry.TopicRecordNameStrategy,
+			schema:   `["null","string"]`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := avro.MustParse(tt.schema)
+
+			got, err := tt.strategy("orders", tt.isKey, schema)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package avro
 import (
 	"crypto/md5"
 	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"hash"
@@ -44,14 +45,18 @@ type LogicalType string
 
 // Schema logical type constants.
 const (
-	Decimal         LogicalType = "decimal"
-	UUID            LogicalType = "uuid"
-	Date            LogicalType = "date"
-	TimeMillis      LogicalType = "time-millis"
-	TimeMicros      LogicalType = "time-micros"
-	TimestampMillis LogicalType = "timestamp-millis"
-	TimestampMicros LogicalType = "timestamp-micros"
-	Duration        LogicalType = "duration"
+	Decimal              LogicalType = "decimal"
+	UUID                 LogicalType = "uuid"
+	Date                 LogicalType = "date"
+	TimeMillis           LogicalType = "time-millis"
+	TimeMicros           LogicalType = "time-micros"
+	TimestampMillis      LogicalType = "timestamp-millis"
+	TimestampMicros      LogicalType = "timestamp-micros"
+	TimestampNanos       LogicalType = "timestamp-nanos"
+	LocalTimestampMillis LogicalType = "local-timestamp-millis"
+	LocalTimestampMicros LogicalType = "local-timestamp-micros"
+	LocalTimestampNanos  LogicalType = "local-timestamp-nanos"
+	Duration             LogicalType = "duration"
 )
 
 // FingerprintType is a fingerprinting algorithm.
@@ -102,6 +107,10 @@ func (s Schemas) Get(name string) (Schema, int) {
 		if namedSchema, ok := schema.(NamedSchema); ok && namedSchema.FullName() == name {
 			return schema, i
 		}
+
+		if lt := getLogicalType(schema); lt != "" && string(schema.Type())+"."+string(lt) == name {
+			return schema, i
+		}
 	}
 
 	return nil, -1
@@ -143,6 +152,8 @@ type PropertySchema interface {
 }
 
 // NamedSchema represents a schema with a name.
+//
+// The record, enum and fixed schemas also return their full qualified aliases from Aliases.
 type NamedSchema interface {
 	Schema
 	PropertySchema
@@ -163,10 +174,42 @@ type LogicalTypeSchema interface {
 	Logical() LogicalSchema
 }
 
+// SchemaFunc is a function used to customise a schema or field.
+type SchemaFunc func(*schemaConfig)
+
+type schemaConfig struct {
+	aliases []string
+	def     string
+}
+
+// WithAliases sets the aliases of a named schema or field.
+func WithAliases(aliases []string) SchemaFunc {
+	return func(cfg *schemaConfig) {
+		cfg.aliases = aliases
+	}
+}
+
+// WithDefault sets the default symbol of an enum schema.
+func WithDefault(def string) SchemaFunc {
+	return func(cfg *schemaConfig) {
+		cfg.def = def
+	}
+}
+
+func newSchemaConfig(opts []SchemaFunc) schemaConfig {
+	var cfg schemaConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
 type name struct {
-	name  string
-	space string
-	full  string
+	name    string
+	space   string
+	full    string
+	aliases []string
 }
 
 func newName(n, s string) (name, error) {
@@ -193,6 +236,24 @@ func newName(n, s string) (name, error) {
 	}, nil
 }
 
+func newAliases(aliases []string, space string) ([]string, error) {
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+
+	full := make([]string, len(aliases))
+	for i, alias := range aliases {
+		n, err := newName(alias, space)
+		if err != nil {
+			return nil, fmt.Errorf("avro: invalid alias %s", alias)
+		}
+
+		full[i] = n.full
+	}
+
+	return full, nil
+}
+
 // Name returns the name of a schema.
 func (n name) Name() string {
 	return n.name
@@ -208,8 +269,14 @@ func (n name) FullName() string {
 	return n.full
 }
 
+// Aliases returns the full qualified aliases of a schema.
+func (n name) Aliases() []string {
+	return n.aliases
+}
+
 type fingerprinter struct {
 	fingerprint atomic.Value   // [32]byte
+	full        atomic.Value   // [32]byte
 	cache       concurrent.Map // map[FingerprintType][]byte
 }
 
@@ -224,6 +291,24 @@ func (f *fingerprinter) Fingerprint(stringer fmt.Stringer) [32]byte {
 	return fingerprint
 }
 
+// setFingerprint sets the SHA256 fingerprint of the schema.
+//
+// This is used by resolved schemas, which cannot be distinguished by their canonical form.
+func (f *fingerprinter) setFingerprint(fingerprint [32]byte) {
+	f.fingerprint.Store(fingerprint)
+}
+
+// fingerprintFull returns the SHA256 fingerprint of the full form of the schema.
+func (f *fingerprinter) fingerprintFull(schema Schema) [32]byte {
+	if v := f.full.Load(); v != nil {
+		return v.([32]byte)
+	}
+
+	fingerprint := sha256.Sum256([]byte(fullString(schema)))
+	f.full.Store(fingerprint)
+	return fingerprint
+}
+
 // FingerprintUsing returns the fingerprint of the schema using the given algorithm or an error.
 func (f *fingerprinter) FingerprintUsing(typ FingerprintType, stringer fmt.Stringer) ([]byte, error) {
 	if v, ok := f.cache.Load(typ); ok {
@@ -286,6 +371,9 @@ type PrimitiveSchema struct {
 
 	typ     Type
 	logical LogicalSchema
+
+	// encodedType is set on a resolved schema when the writer type was promoted.
+	encodedType Type
 }
 
 // NewPrimitiveSchema creates a new PrimitiveSchema.
@@ -336,11 +424,17 @@ type RecordSchema struct {
 }
 
 // NewRecordSchema creates a new record schema instance.
-func NewRecordSchema(name, space string, fields []*Field) (*RecordSchema, error) {
+func NewRecordSchema(name, space string, fields []*Field, opts ...SchemaFunc) (*RecordSchema, error) {
+	cfg := newSchemaConfig(opts)
+
 	n, err := newName(name, space)
 	if err != nil {
 		return nil, err
 	}
+	n.aliases, err = newAliases(cfg.aliases, n.space)
+	if err != nil {
+		return nil, err
+	}
 
 	return &RecordSchema{
 		name:       n,
@@ -350,11 +444,17 @@ func NewRecordSchema(name, space string, fields []*Field) (*RecordSchema, error)
 }
 
 // NewErrorRecordSchema creates a new error record schema instance.
-func NewErrorRecordSchema(name, space string, fields []*Field) (*RecordSchema, error) {
+func NewErrorRecordSchema(name, space string, fields []*Field, opts ...SchemaFunc) (*RecordSchema, error) {
+	cfg := newSchemaConfig(opts)
+
 	n, err := newName(name, space)
 	if err != nil {
 		return nil, err
 	}
+	n.aliases, err = newAliases(cfg.aliases, n.space)
+	if err != nil {
+		return nil, err
+	}
 
 	return &RecordSchema{
 		name:       n,
@@ -415,22 +515,46 @@ type Field struct {
 	typ    Schema
 	hasDef bool
 	def    interface{}
+
+	aliases []string
+
+	// action and encodedDef are only set on the fields of a resolved record.
+	action     fieldAction
+	encodedDef []byte
 }
 
+type fieldAction int
+
+const (
+	fieldActionNone fieldAction = iota
+	// fieldActionDrain skips a field that only exists in the writer schema.
+	fieldActionDrain
+	// fieldActionSetDefault sets a field that only exists in the reader schema to its default.
+	fieldActionSetDefault
+)
+
 type noDef struct{}
 
 // NoDefault is used when no default exists for a field.
 var NoDefault = noDef{}
 
 // NewField creates a new field instance.
-func NewField(name string, typ Schema, def interface{}) (*Field, error) {
+func NewField(name string, typ Schema, def interface{}, opts ...SchemaFunc) (*Field, error) {
+	cfg := newSchemaConfig(opts)
+
 	if err := validateName(name); err != nil {
 		return nil, err
 	}
+	for _, alias := range cfg.aliases {
+		if err := validateName(alias); err != nil {
+			return nil, fmt.Errorf("avro: invalid alias %s", alias)
+		}
+	}
 
 	f := &Field{
 		properties: properties{reserved: fieldReserved},
 		name:       name,
+		aliases:    cfg.aliases,
 		typ:        typ,
 	}
 
@@ -451,6 +575,11 @@ func (s *Field) Name() string {
 	return s.name
 }
 
+// Aliases returns the aliases of a field.
+func (s *Field) Aliases() []string {
+	return s.aliases
+}
+
 // Type returns the schema of a field.
 func (s *Field) Type() Schema {
 	return s.typ
@@ -484,14 +613,25 @@ type EnumSchema struct {
 	fingerprinter
 
 	symbols []string
+	def     string
+
+	// encodedSymbols is only set on a resolved enum. It holds the reader
+	// symbol for each writer symbol, in writer order.
+	encodedSymbols []string
 }
 
 // NewEnumSchema creates a new enum schema instance.
-func NewEnumSchema(name, namespace string, symbols []string) (*EnumSchema, error) {
+func NewEnumSchema(name, namespace string, symbols []string, opts ...SchemaFunc) (*EnumSchema, error) {
+	cfg := newSchemaConfig(opts)
+
 	n, err := newName(name, namespace)
 	if err != nil {
 		return nil, err
 	}
+	n.aliases, err = newAliases(cfg.aliases, n.space)
+	if err != nil {
+		return nil, err
+	}
 
 	if len(symbols) == 0 {
 		return nil, errors.New("avro: enum must have a non-empty array of symbols")
@@ -501,11 +641,24 @@ func NewEnumSchema(name, namespace string, symbols []string) (*EnumSchema, error
 			return nil, fmt.Errorf("avro: invalid symnol %s", symbol)
 		}
 	}
+	if cfg.def != "" {
+		var found bool
+		for _, symbol := range symbols {
+			if symbol == cfg.def {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("avro: default symbol %s is not a symbol of enum %s", cfg.def, n.full)
+		}
+	}
 
 	return &EnumSchema{
 		name:       n,
-		properties: properties{reserved: schemaReserved},
+		properties: properties{reserved: enumReserved},
 		symbols:    symbols,
+		def:        cfg.def,
 	}, nil
 }
 
@@ -519,6 +672,21 @@ func (s *EnumSchema) Symbols() []string {
 	return s.symbols
 }
 
+// Default returns the default symbol of an enum, or an empty string
+// if the enum has no default.
+func (s *EnumSchema) Default() string {
+	return s.def
+}
+
+// decodedSymbols returns the symbols an encoded index resolves to.
+func (s *EnumSchema) decodedSymbols() []string {
+	if s.encodedSymbols != nil {
+		return s.encodedSymbols
+	}
+
+	return s.symbols
+}
+
 // String returns the canonical form of the schema.
 func (s *EnumSchema) String() string {
 	symbols := ""
@@ -629,6 +797,14 @@ type UnionSchema struct {
 	fingerprinter
 
 	types Schemas
+
+	// flat is set on a resolved union when the reader schema is not a union.
+	// Each type is decoded directly into the reader type.
+	flat bool
+	// fixed is set on a resolved union when the writer schema is not a union.
+	// The union index is not encoded, fixedIdx is used instead.
+	fixed    bool
+	fixedIdx int
 }
 
 // NewUnionSchema creates a union schema instance.
@@ -718,11 +894,17 @@ type FixedSchema struct {
 }
 
 // NewFixedSchema creates a new fixed schema instance.
-func NewFixedSchema(name, namespace string, size int, logical LogicalSchema) (*FixedSchema, error) {
+func NewFixedSchema(name, namespace string, size int, logical LogicalSchema, opts ...SchemaFunc) (*FixedSchema, error) {
+	cfg := newSchemaConfig(opts)
+
 	n, err := newName(name, namespace)
 	if err != nil {
 		return nil, err
 	}
+	n.aliases, err = newAliases(cfg.aliases, n.space)
+	if err != nil {
+		return nil, err
+	}
 
 	return &FixedSchema{
 		name:       n,
@@ -893,6 +1075,90 @@ func (s *DecimalLogicalSchema) String() string {
 	return `"logicalType":"` + string(Decimal) + `","precision":` + precision + scale
 }
 
+// fullFingerprint returns the SHA256 fingerprint of the full form of the schema.
+//
+// Unlike the canonical fingerprint, it distinguishes schemas that resolve differently,
+// and is used to cache schema resolution.
+func fullFingerprint(schema Schema) [32]byte {
+	schema = derefSchema(schema)
+	if f, ok := schema.(interface{ fingerprintFull(Schema) [32]byte }); ok {
+		return f.fingerprintFull(schema)
+	}
+
+	return sha256.Sum256([]byte(fullString(schema)))
+}
+
+// fullString returns the canonical form of the schema, extended with the attributes
+// used in schema resolution: aliases, field and enum defaults.
+func fullString(schema Schema) string {
+	switch s := schema.(type) {
+	case *RecordSchema:
+		fields := make([]string, len(s.fields))
+		for i, f := range s.fields {
+			var def string
+			if f.hasDef {
+				def = `,"default":` + defaultString(f.Default())
+			}
+			fields[i] = `{"name":"` + f.name + `"` + aliasesString(f.aliases) + `,"type":` + fullString(f.typ) + def + `}`
+		}
+
+		typ := "record"
+		if s.isError {
+			typ = "error"
+		}
+
+		return `{"name":"` + s.FullName() + `"` + aliasesString(s.aliases) + `,"type":"` + typ +
+			`","fields":[` + strings.Join(fields, ",") + `]}`
+
+	case *EnumSchema:
+		var def string
+		if s.def != "" {
+			def = `,"default":"` + s.def + `"`
+		}
+
+		str := s.String()
+		return str[:len(str)-1] + aliasesString(s.aliases) + def + `}`
+
+	case *FixedSchema:
+		str := s.String()
+		return str[:len(str)-1] + aliasesString(s.aliases) + `}`
+
+	case *ArraySchema:
+		return `{"type":"array","items":` + fullString(s.items) + `}`
+
+	case *MapSchema:
+		return `{"type":"map","values":` + fullString(s.values) + `}`
+
+	case *UnionSchema:
+		types := make([]string, len(s.types))
+		for i, typ := range s.types {
+			types[i] = fullString(typ)
+		}
+
+		return `[` + strings.Join(types, ",") + `]`
+
+	default:
+		return schema.String()
+	}
+}
+
+func aliasesString(aliases []string) string {
+	if len(aliases) == 0 {
+		return ""
+	}
+
+	return `,"aliases":["` + strings.Join(aliases, `","`) + `"]`
+}
+
+func defaultString(def interface{}) string {
+	b, err := json.Marshal(def)
+	if err != nil {
+		return fmt.Sprintf("%q", fmt.Sprint(def))
+	}
+
+	return string(b)
+}
+
 func invalidNameFirstChar(r rune) bool {
 	return (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') && r != '_'
 }
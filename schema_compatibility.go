@@ -1,6 +1,7 @@
 package avro
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 
@@ -13,6 +14,8 @@ func (e recursionError) Error() string {
 	return ""
 }
 
+// compatKey identifies a reader and writer schema pair by their full fingerprints,
+// as schemas with the same canonical form can resolve differently.
 type compatKey struct {
 	reader [32]byte
 	writer [32]byte
@@ -20,13 +23,15 @@ type compatKey struct {
 
 // SchemaCompatibility determines the compatibility of schemas.
 type SchemaCompatibility struct {
-	cache *concurrent.Map // map[compatKey]error
+	cache    *concurrent.Map // map[compatKey]error
+	resolved *concurrent.Map // map[compatKey]Schema
 }
 
 // NewSchemaCompatibility creates a new schema compatibility instance.
 func NewSchemaCompatibility() *SchemaCompatibility {
 	return &SchemaCompatibility{
-		cache: concurrent.NewMap(),
+		cache:    concurrent.NewMap(),
+		resolved: concurrent.NewMap(),
 	}
 }
 
@@ -36,7 +41,7 @@ func (c *SchemaCompatibility) Compatible(reader, writer Schema) error {
 }
 
 func (c *SchemaCompatibility) compatible(reader, writer Schema) error {
-	key := compatKey{reader: reader.Fingerprint(), writer: writer.Fingerprint()}
+	key := compatKey{reader: fullFingerprint(reader), writer: fullFingerprint(writer)}
 	if err, ok := c.cache.Load(key); ok {
 		if _, ok := err.(recursionError); ok {
 			// Break the recursion here.
@@ -179,7 +184,7 @@ func (c *SchemaCompatibility) match(reader, writer Schema) error {
 }
 
 func (c *SchemaCompatibility) checkSchemaName(reader, writer NamedSchema) error {
-	if reader.FullName() != writer.FullName() {
+	if reader.FullName() != writer.FullName() && !c.contains(schemaAliases(reader), writer.FullName()) {
 		return fmt.Errorf("reader schema %s and writer schema %s  names do match", reader.FullName(), writer.FullName())
 	}
 
@@ -195,6 +200,11 @@ func (c *SchemaCompatibility) checkFixedSize(reader, writer *FixedSchema) error
 }
 
 func (c *SchemaCompatibility) checkEnumSymbols(reader, writer *EnumSchema) error {
+	// Unknown writer symbols resolve to the reader default.
+	if reader.Default() != "" {
+		return nil
+	}
+
 	for _, symbol := range writer.Symbols() {
 		if !c.contains(reader.Symbols(), symbol) {
 			return fmt.Errorf("reader %s is missing symbol %s", reader.FullName(), symbol)
@@ -233,6 +243,8 @@ func (c *SchemaCompatibility) contains(a []string, s string) bool {
 	return false
 }
 
+// getField gets the writer field matching the reader field f, by name or by
+// one of the reader field aliases.
 func (c *SchemaCompatibility) getField(a []*Field, f *Field) (*Field, bool) {
 	for _, field := range a {
 		if field.Name() == f.Name() {
@@ -240,5 +252,309 @@ func (c *SchemaCompatibility) getField(a []*Field, f *Field) (*Field, bool) {
 		}
 	}
 
+	for _, field := range a {
+		if c.contains(f.Aliases(), field.Name()) {
+			return field, true
+		}
+	}
+
+	return nil, false
+}
+
+// getReaderField gets the reader field matching the writer field f, by name or
+// by one of the reader field aliases.
+func (c *SchemaCompatibility) getReaderField(a []*Field, f *Field) (*Field, bool) {
+	for _, field := range a {
+		if field.Name() == f.Name() {
+			return field, true
+		}
+	}
+
+	for _, field := range a {
+		if c.contains(field.Aliases(), f.Name()) {
+			return field, true
+		}
+	}
+
 	return nil, false
 }
+
+// Resolve returns a composite schema that allows decoding data written with the
+// writer schema into a type described by the reader schema, following the Avro
+// schema resolution rules.
+//
+// The returned schema should only be used for decoding. Resolve returns an error if
+// the reader and writer schemas are not compatible.
+func (c *SchemaCompatibility) Resolve(reader, writer Schema) (Schema, error) {
+	key := compatKey{reader: fullFingerprint(reader), writer: fullFingerprint(writer)}
+	if schema, ok := c.resolved.Load(key); ok {
+		return schema.(Schema), nil
+	}
+
+	if err := c.compatible(reader, writer); err != nil {
+		return nil, err
+	}
+
+	schema, err := c.resolve(reader, writer, map[compatKey]NamedSchema{})
+	if err != nil {
+		return nil, err
+	}
+
+	c.resolved.Store(key, schema)
+	return schema, nil
+}
+
+func (c *SchemaCompatibility) resolve(reader, writer Schema, seen map[compatKey]NamedSchema) (Schema, error) {
+	// If the schema is a reference, get the actual schema
+	if reader.Type() == Ref {
+		reader = reader.(*RefSchema).Schema()
+	}
+	if writer.Type() == Ref {
+		writer = writer.(*RefSchema).Schema()
+	}
+
+	// Identical schemas need no resolution
+	if reader.Fingerprint() == writer.Fingerprint() {
+		return reader, nil
+	}
+
+	key := compatKey{reader: fullFingerprint(reader), writer: fullFingerprint(writer)}
+	if schema, ok := seen[key]; ok {
+		// Break the recursion here.
+		return NewRefSchema(schema), nil
+	}
+
+	if reader.Type() != writer.Type() {
+		if writer.Type() == Union {
+			return c.resolveWriterUnion(reader, writer.(*UnionSchema), seen)
+		}
+
+		if reader.Type() == Union {
+			return c.resolveReaderUnion(reader.(*UnionSchema), writer, seen)
+		}
+
+		// The writer type is promoted to the reader type.
+		prim := &PrimitiveSchema{
+			typ:         reader.Type(),
+			logical:     getLogicalSchema(reader),
+			encodedType: writer.Type(),
+		}
+		prim.setFingerprint(resolvedFingerprint(reader, writer))
+		return prim, nil
+	}
+
+	switch reader.Type() {
+	case Array:
+		items, err := c.resolve(reader.(*ArraySchema).Items(), writer.(*ArraySchema).Items(), seen)
+		if err != nil {
+			return nil, err
+		}
+
+		arr := NewArraySchema(items)
+		arr.setFingerprint(resolvedFingerprint(reader, writer))
+		return arr, nil
+
+	case Map:
+		values, err := c.resolve(reader.(*MapSchema).Values(), writer.(*MapSchema).Values(), seen)
+		if err != nil {
+			return nil, err
+		}
+
+		ms := NewMapSchema(values)
+		ms.setFingerprint(resolvedFingerprint(reader, writer))
+		return ms, nil
+
+	case Enum:
+		return c.resolveEnum(reader.(*EnumSchema), writer.(*EnumSchema)), nil
+
+	case Record:
+		return c.resolveRecord(reader.(*RecordSchema), writer.(*RecordSchema), seen)
+
+	case Union:
+		return c.resolveWriterUnion(reader, writer.(*UnionSchema), seen)
+	}
+
+	return reader, nil
+}
+
+func (c *SchemaCompatibility) resolveEnum(reader, writer *EnumSchema) Schema {
+	// Symbols are resolved by name, writer symbols unknown to the reader
+	// resolve to the reader default.
+	symbols := make([]string, len(writer.Symbols()))
+	for i, symbol := range writer.Symbols() {
+		if !c.contains(reader.Symbols(), symbol) {
+			symbol = reader.Default()
+		}
+		symbols[i] = symbol
+	}
+
+	enum := &EnumSchema{
+		name:           reader.name,
+		properties:     reader.properties,
+		symbols:        reader.symbols,
+		def:            reader.def,
+		encodedSymbols: symbols,
+	}
+	enum.setFingerprint(resolvedFingerprint(reader, writer))
+	return enum
+}
+
+func (c *SchemaCompatibility) resolveRecord(reader, writer *RecordSchema, seen map[compatKey]NamedSchema) (Schema, error) {
+	rec := &RecordSchema{
+		name:       reader.name,
+		properties: reader.properties,
+		isError:    reader.isError,
+	}
+	rec.setFingerprint(resolvedFingerprint(reader, writer))
+
+	key := compatKey{reader: fullFingerprint(reader), writer: fullFingerprint(writer)}
+	seen[key] = rec
+
+	fields := make([]*Field, 0, len(writer.Fields()))
+	for _, wf := range writer.Fields() {
+		rf, ok := c.getReaderField(reader.Fields(), wf)
+		if !ok {
+			// The field only exists in the writer, it must be skipped.
+			fields = append(fields, &Field{
+				properties: wf.properties,
+				name:       wf.name,
+				typ:        wf.typ,
+				action:     fieldActionDrain,
+			})
+			continue
+		}
+
+		typ, err := c.resolve(rf.Type(), wf.Type(), seen)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, &Field{
+			properties: rf.properties,
+			name:       rf.name,
+			aliases:    rf.aliases,
+			typ:        typ,
+			hasDef:     rf.hasDef,
+			def:        rf.def,
+		})
+	}
+
+	for _, rf := range reader.Fields() {
+		if _, ok := c.getField(writer.Fields(), rf); ok {
+			continue
+		}
+
+		// The field only exists in the reader, it must be set from its default.
+		def, err := encodeDefault(rf)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, &Field{
+			properties: rf.properties,
+			name:       rf.name,
+			aliases:    rf.aliases,
+			typ:        rf.typ,
+			hasDef:     rf.hasDef,
+			def:        rf.def,
+			action:     fieldActionSetDefault,
+			encodedDef: def,
+		})
+	}
+	rec.fields = fields
+
+	return rec, nil
+}
+
+func (c *SchemaCompatibility) resolveWriterUnion(reader Schema, writer *UnionSchema, seen map[compatKey]NamedSchema) (Schema, error) {
+	readerUnion, isUnion := reader.(*UnionSchema)
+
+	types := make([]Schema, len(writer.Types()))
+	for i, wt := range writer.Types() {
+		rt := reader
+		if isUnion {
+			rt, _ = c.getUnionType(readerUnion, wt)
+			if rt == nil {
+				return nil, fmt.Errorf("reader union lacking writer schema %s", wt.Type())
+			}
+		}
+
+		typ, err := c.resolve(rt, wt, seen)
+		if err != nil {
+			return nil, err
+		}
+		types[i] = typ
+	}
+
+	union := &UnionSchema{
+		types: types,
+		flat:  !isUnion,
+	}
+	union.setFingerprint(resolvedFingerprint(reader, writer))
+	return union, nil
+}
+
+func (c *SchemaCompatibility) resolveReaderUnion(reader *UnionSchema, writer Schema, seen map[compatKey]NamedSchema) (Schema, error) {
+	rt, idx := c.getUnionType(reader, writer)
+	if rt == nil {
+		return nil, fmt.Errorf("reader union lacking writer schema %s", writer.Type())
+	}
+
+	typ, err := c.resolve(rt, writer, seen)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]Schema, len(reader.Types()))
+	copy(types, reader.Types())
+	types[idx] = typ
+
+	union := &UnionSchema{
+		types:    types,
+		fixed:    true,
+		fixedIdx: idx,
+	}
+	union.setFingerprint(resolvedFingerprint(reader, writer))
+	return union, nil
+}
+
+// getUnionType gets the first type in the reader union that matches the
+// writer schema, preferring types that match without promotion.
+func (c *SchemaCompatibility) getUnionType(reader *UnionSchema, writer Schema) (Schema, int) {
+	name := schemaTypeName(derefSchema(writer))
+	for i, typ := range reader.Types() {
+		if schemaTypeName(derefSchema(typ)) == name {
+			return typ, i
+		}
+	}
+
+	for i, typ := range reader.Types() {
+		if err := c.compatible(typ, writer); err == nil {
+			return typ, i
+		}
+	}
+
+	return nil, -1
+}
+
+// schemaAliases returns the full qualified aliases of a named schema.
+func schemaAliases(schema NamedSchema) []string {
+	if s, ok := schema.(interface{ Aliases() []string }); ok {
+		return s.Aliases()
+	}
+
+	return nil
+}
+
+func derefSchema(schema Schema) Schema {
+	if ref, ok := schema.(*RefSchema); ok {
+		return ref.Schema()
+	}
+
+	return schema
+}
+
+func resolvedFingerprint(reader, writer Schema) [32]byte {
+	r, w := fullFingerprint(reader), fullFingerprint(writer)
+	return sha256.Sum256(append(r[:], w[:]...))
+}
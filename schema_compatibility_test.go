@@ -176,6 +176,12 @@ func TestSchemaCompatibility_Compatible(t *testing.T) {
 			writer:  `{"type":"enum", "name":"test", "namespace": "org.hamba.avro", "symbols":["TEST1", "TEST2"]}`,
 			wantErr: true,
 		},
+		{
+			name:    "Enum Reader Missing Symbol With Default",
+			reader:  `{"type":"enum", "name":"test", "namespace": "org.hamba.avro", "symbols":["TEST1", "UNKNOWN"], "default": "UNKNOWN"}`,
+			writer:  `{"type":"enum", "name":"test", "namespace": "org.hamba.avro", "symbols":["TEST1", "TEST2"]}`,
+			wantErr: false,
+		},
 		{
 			name:    "Enum Writer Missing Symbol",
 			reader:  `{"type":"enum", "name":"test", "namespace": "org.hamba.avro", "symbols":["TEST1", "TEST2"]}`,
@@ -218,6 +224,30 @@ func TestSchemaCompatibility_Compatible(t *testing.T) {
 			writer:  `{"type":"record", "name":"test", "namespace": "org.hamba.avro", "fields":[{"name": "a", "type": "int"}]}`,
 			wantErr: true,
 		},
+		{
+			name:    "Record Name Alias",
+			reader:  `{"type":"record", "name":"test1", "namespace": "org.hamba.avro", "aliases": ["test"], "fields":[{"name": "a", "type": "int"}]}`,
+			writer:  `{"type":"record", "name":"test", "namespace": "org.hamba.avro", "fields":[{"name": "a", "type": "int"}]}`,
+			wantErr: false,
+		},
+		{
+			name:    "Record Field Alias",
+			reader:  `{"type":"record", "name":"test", "namespace": "org.hamba.avro", "fields":[{"name": "c", "aliases": ["a"], "type": "int"}]}`,
+			writer:  `{"type":"record", "name":"test", "namespace": "org.hamba.avro", "fields":[{"name": "a", "type": "int"}]}`,
+			wantErr: false,
+		},
+		{
+			name:    "Enum Name Alias",
+			reader:  `{"type":"enum", "name":"test1", "namespace": "org.hamba.avro", "aliases": ["test"], "symbols":["TEST1", "TEST2"]}`,
+			writer:  `{"type":"enum", "name":"test", "namespace": "org.hamba.avro", "symbols":["TEST1", "TEST2"]}`,
+			wantErr: false,
+		},
+		{
+			name:    "Fixed Name Alias",
+			reader:  `{"type":"fixed", "name":"test1", "namespace": "org.hamba.avro", "aliases": ["test"], "size": 12}`,
+			writer:  `{"type":"fixed", "name":"test", "namespace": "org.hamba.avro", "size": 12}`,
+			wantErr: false,
+		},
 		{
 			name:    "Ref Dereference",
 			reader:  `{"type":"record", "name":"test", "namespace": "org.hamba.avro", "fields":[{"name": "a", "type": {"type":"record", "name":"test1", "namespace": "org.hamba.avro", "fields":[{"name": "b", "type": "int"}]}}, {"name": "b", "type": "test1"}]}`,
@@ -279,3 +309,115 @@ func TestSchemaCompatibility_CompatibleUsesCacheWithError(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestSchemaCompatibility_Resolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		reader  string
+		writer  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Primitive Matching",
+			reader: `"int"`,
+			writer: `"int"`,
+			want:   `"int"`,
+		},
+		{
+			name:   "Record Reordered Fields",
+			reader: `{"type":"record", "name":"test", "namespace": "org.hamba.avro", "fields":[{"name": "a", "type": "int"}, {"name": "b", "type": "string"}]}`,
+			writer: `{"type":"record", "name":"test", "namespace": "org.hamba.avro", "fields":[{"name": "b", "type": "string"}, {"name": "a", "type": "int"}]}`,
+			want:   `{"name":"org.hamba.avro.test","type":"record","fields":[{"name":"b","type":"string"},{"name":"a","type":"int"}]}`,
+		},
+		{
+			name:   "Union Reordered",
+			reader: `["null", "string"]`,
+			writer: `["string", "null"]`,
+			want:   `["string","null"]`,
+		},
+		{
+			name:   "Reader Union",
+			reader: `["null", "string"]`,
+			writer: `"string"`,
+			want:   `["null","string"]`,
+		},
+		{
+			name:   "Breaks Recursion",
+			reader: `{"type":"record", "name":"test", "namespace": "org.hamba.avro", "fields":[{"name": "a", "type": "int"}, {"name": "b", "type": ["null", "test"]}]}`,
+			writer: `{"type":"record", "name":"test", "namespace": "org.hamba.avro", "fields":[{"name": "b", "type": ["null", "test"]}, {"name": "a", "type": "int"}]}`,
+			want:   `{"name":"org.hamba.avro.test","type":"record","fields":[{"name":"b","type":["null","org.hamba.avro.test"]},{"name":"a","type":"int"}]}`,
+		},
+		{
+			name:    "Incompatible",
+			reader:  `"int"`,
+			writer:  `"string"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := avro.MustParse(tt.reader)
+			w := avro.MustParse(tt.writer)
+			sc := avro.NewSchemaCompatibility()
+
+			got, err := sc.Resolve(r, w)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestSchemaCompatibility_CompatibleCacheDistinguishesDefaults(t *testing.T) {
+	noDef := avro.MustParse(`{"type":"record", "name":"test", "fields":[{"name": "a", "type": "int"}, {"name": "b", "type": "int"}]}`)
+	withDef := avro.MustParse(`{"type":"record", "name":"test", "fields":[{"name": "a", "type": "int"}, {"name": "b", "type": "int", "default": 1}]}`)
+	w := avro.MustParse(`{"type":"record", "name":"test", "fields":[{"name": "a", "type": "int"}]}`)
+	sc := avro.NewSchemaCompatibility()
+
+	_ = sc.Compatible(noDef, w)
+
+	err := sc.Compatible(withDef, w)
+
+	assert.NoError(t, err)
+}
+
+func TestSchemaCompatibility_CompatibleCacheDistinguishesAliases(t *testing.T) {
+	noAlias := avro.MustParse(`{"type":"record", "name":"test", "fields":[{"name": "a", "type": "int"}]}`)
+	withAlias := avro.MustParse(`{"type":"record", "name":"test", "aliases":["old"], "fields":[{"name": "a", "type": "int"}]}`)
+	w := avro.MustParse(`{"type":"record", "name":"old", "fields":[{"name": "a", "type": "int"}]}`)
+	sc := avro.NewSchemaCompatibility()
+
+	_, _ = sc.Resolve(noAlias, w)
+
+	_, err := sc.Resolve(withAlias, w)
+
+	assert.NoError(t, err)
+}
+
+func TestSchemaCompatibility_ResolveUsesCache(t *testing.T) {
+	r := avro.MustParse(`["null", "string"]`)
+	w := avro.MustParse(`"string"`)
+	sc := avro.NewSchemaCompatibility()
+
+	first, _ := sc.Resolve(r, w)
+	second, _ := sc.Resolve(r, w)
+
+	assert.Same(t, first, second)
+}
+
+func TestSchemaCompatibility_ResolveFingerprintDiffersFromReader(t *testing.T) {
+	r := avro.MustParse(`["null", "string"]`)
+	w := avro.MustParse(`"string"`)
+	sc := avro.NewSchemaCompatibility()
+
+	got, _ := sc.Resolve(r, w)
+
+	assert.NotEqual(t, r.Fingerprint(), got.Fingerprint())
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/concurrent"
 )
 
 var (
@@ -16,8 +17,37 @@ var (
 		"values", "type", "aliases", "logicalType", "precision", "scale",
 	}
 	fieldReserved = []string{"default", "doc", "name", "order", "type", "aliases"}
+	enumReserved  = append(append([]string{}, schemaReserved...), "default")
 )
 
+// logicalTypes holds the registered custom logical types.
+var logicalTypes = concurrent.NewMap() // map[LogicalType]customLogicalType
+
+// LogicalTypeParser parses the logical schema of a custom logical type from the properties
+// of the schema it annotates. If the properties are invalid, nil should be returned, and
+// the logical type is ignored.
+type LogicalTypeParser func(typ Type, props map[string]interface{}) LogicalSchema
+
+type customLogicalType struct {
+	types []Type
+	parse LogicalTypeParser
+}
+
+// RegisterLogicalType registers a custom logical type annotating the given types, so it is
+// kept when parsing schemas instead of being ignored. If parse is nil, the logical type
+// has no properties.
+//
+// The logical types of the specification take precedence over registered logical types.
+func RegisterLogicalType(name LogicalType, types []Type, parse LogicalTypeParser) {
+	if parse == nil {
+		parse = func(Type, map[string]interface{}) LogicalSchema {
+			return NewPrimitiveLogicalSchema(name)
+		}
+	}
+
+	logicalTypes.Store(name, customLogicalType{types: types, parse: parse})
+}
+
 // DefaultSchemaCache is the default cache for schemas.
 var DefaultSchemaCache = &SchemaCache{}
 
@@ -163,7 +193,11 @@ func parsePrimitiveLogicalType(typ Type, m map[string]interface{}) LogicalSchema
 		(typ == Int && ltyp == TimeMillis) ||
 		(typ == Long && ltyp == TimeMicros) ||
 		(typ == Long && ltyp == TimestampMillis) ||
-		(typ == Long && ltyp == TimestampMicros) {
+		(typ == Long && ltyp == TimestampMicros) ||
+		(typ == Long && ltyp == TimestampNanos) ||
+		(typ == Long && ltyp == LocalTimestampMillis) ||
+		(typ == Long && ltyp == LocalTimestampMicros) ||
+		(typ == Long && ltyp == LocalTimestampNanos) {
 		return NewPrimitiveLogicalSchema(ltyp)
 	}
 
@@ -171,7 +205,7 @@ func parsePrimitiveLogicalType(typ Type, m map[string]interface{}) LogicalSchema
 		return parseDecimalLogicalType(-1, m)
 	}
 
-	return nil
+	return parseCustomLogicalType(typ, ltyp, m)
 }
 
 func parseRecord(typ Type, namespace string, m map[string]interface{}, cache *SchemaCache) (Schema, error) {
@@ -189,13 +223,18 @@ func parseRecord(typ Type, namespace string, m map[string]interface{}, cache *Sc
 	}
 	fields := make([]*Field, len(fs))
 
+	aliases, err := resolveAliases(m)
+	if err != nil {
+		return nil, err
+	}
+
 	var rec *RecordSchema
 	switch typ {
 	case Record:
-		rec, err = NewRecordSchema(name, namespace, fields)
+		rec, err = NewRecordSchema(name, namespace, fields, WithAliases(aliases))
 
 	case Error:
-		rec, err = NewErrorRecordSchema(name, namespace, fields)
+		rec, err = NewErrorRecordSchema(name, namespace, fields, WithAliases(aliases))
 	}
 	if err != nil {
 		return nil, err
@@ -244,7 +283,12 @@ func parseField(namespace string, v interface{}, cache *SchemaCache) (*Field, er
 		def = NoDefault
 	}
 
-	field, err := NewField(name, typ, def)
+	aliases, err := resolveAliases(m)
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := NewField(name, typ, def, WithAliases(aliases))
 	if err != nil {
 		return nil, err
 	}
@@ -280,7 +324,22 @@ func parseEnum(namespace string, m map[string]interface{}, cache *SchemaCache) (
 		symbols[i] = str
 	}
 
-	enum, err := NewEnumSchema(name, namespace, symbols)
+	aliases, err := resolveAliases(m)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []SchemaFunc{WithAliases(aliases)}
+	if v, ok := m["default"]; ok {
+		def, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("avro: invalid default symbol: %+v", v)
+		}
+
+		opts = append(opts, WithDefault(def))
+	}
+
+	enum, err := NewEnumSchema(name, namespace, symbols, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -363,7 +422,12 @@ func parseFixed(namespace string, m map[string]interface{}, cache *SchemaCache)
 
 	logical := parseFixedLogicalType(int(size), m)
 
-	fixed, err := NewFixedSchema(name, namespace, int(size), logical)
+	aliases, err := resolveAliases(m)
+	if err != nil {
+		return nil, err
+	}
+
+	fixed, err := NewFixedSchema(name, namespace, int(size), logical, WithAliases(aliases))
 	if err != nil {
 		return nil, err
 	}
@@ -388,11 +452,15 @@ func parseFixedLogicalType(size int, m map[string]interface{}) LogicalSchema {
 		return NewPrimitiveLogicalSchema(Duration)
 	}
 
+	if ltyp == UUID && size == 16 {
+		return NewPrimitiveLogicalSchema(UUID)
+	}
+
 	if ltyp == Decimal {
 		return parseDecimalLogicalType(size, m)
 	}
 
-	return nil
+	return parseCustomLogicalType(Fixed, ltyp, m)
 }
 
 func parseDecimalLogicalType(size int, m map[string]interface{}) LogicalSchema {
@@ -454,3 +522,42 @@ func resolveFullName(m map[string]interface{}) (string, string, error) {
 
 	return name, namespace, nil
 }
+
+func resolveAliases(m map[string]interface{}) ([]string, error) {
+	v, ok := m["aliases"]
+	if !ok {
+		return nil, nil
+	}
+
+	a, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.New("avro: aliases must be an array of strings")
+	}
+
+	aliases := make([]string, len(a))
+	for i, alias := range a {
+		str, ok := alias.(string)
+		if !ok {
+			return nil, fmt.Errorf("avro: invalid alias: %+v", alias)
+		}
+
+		aliases[i] = str
+	}
+
+	return aliases, nil
+}
+
+func parseCustomLogicalType(typ Type, ltyp LogicalType, m map[string]interface{}) LogicalSchema {
+	v, ok := logicalTypes.Load(ltyp)
+	if !ok {
+		return nil
+	}
+
+	custom := v.(customLogicalType)
+	for _, t := range custom.types {
+		if t == typ {
+			return custom.parse(typ, m)
+		}
+	}
+	return nil
+}
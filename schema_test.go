@@ -459,6 +459,115 @@ func TestRecordSchema_WithReference(t *testing.T) {
 	assert.Equal(t, s.Fingerprint(), s.(*avro.RecordSchema).Fields()[1].Type().Fingerprint())
 }
 
+func TestSchema_Aliases(t *testing.T) {
+	tests := []struct {
+		name        string
+		schema      string
+		wantAliases []string
+		wantErr     bool
+	}{
+		{
+			name:        "Record",
+			schema:      `{"type":"record", "name":"test", "namespace": "org.hamba.avro", "aliases": ["test1", "com.example.test2"], "fields":[{"name": "a", "type": "int"}]}`,
+			wantAliases: []string{"org.hamba.avro.test1", "com.example.test2"},
+			wantErr:     false,
+		},
+		{
+			name:        "Error",
+			schema:      `{"type":"error", "name":"test", "namespace": "org.hamba.avro", "aliases": ["test1"], "fields":[{"name": "a", "type": "int"}]}`,
+			wantAliases: []string{"org.hamba.avro.test1"},
+			wantErr:     false,
+		},
+		{
+			name:        "Enum",
+			schema:      `{"type":"enum", "name":"test", "namespace": "org.hamba.avro", "aliases": ["test1"], "symbols":["TEST"]}`,
+			wantAliases: []string{"org.hamba.avro.test1"},
+			wantErr:     false,
+		},
+		{
+			name:        "Fixed",
+			schema:      `{"type":"fixed", "name":"test", "namespace": "org.hamba.avro", "aliases": ["test1"], "size": 12}`,
+			wantAliases: []string{"org.hamba.avro.test1"},
+			wantErr:     false,
+		},
+		{
+			name:        "No Aliases",
+			schema:      `{"type":"fixed", "name":"test", "namespace": "org.hamba.avro", "size": 12}`,
+			wantAliases: nil,
+			wantErr:     false,
+		},
+		{
+			name:    "Invalid Aliases Type",
+			schema:  `{"type":"record", "name":"test", "namespace": "org.hamba.avro", "aliases": "test1", "fields":[{"name": "a", "type": "int"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Alias Type",
+			schema:  `{"type":"enum", "name":"test", "namespace": "org.hamba.avro", "aliases": [1], "symbols":["TEST"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Alias",
+			schema:  `{"type":"fixed", "name":"test", "namespace": "org.hamba.avro", "aliases": ["test+"], "size": 12}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := avro.Parse(tt.schema)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAliases, schema.(interface{ Aliases() []string }).Aliases())
+		})
+	}
+}
+
+func TestField_Aliases(t *testing.T) {
+	tests := []struct {
+		name        string
+		schema      string
+		wantAliases []string
+		wantErr     bool
+	}{
+		{
+			name:        "Valid",
+			schema:      `{"type":"record", "name":"test", "fields":[{"name": "a", "aliases": ["b", "c"], "type": "int"}]}`,
+			wantAliases: []string{"b", "c"},
+			wantErr:     false,
+		},
+		{
+			name:    "Invalid Aliases Type",
+			schema:  `{"type":"record", "name":"test", "fields":[{"name": "a", "aliases": "b", "type": "int"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Alias",
+			schema:  `{"type":"record", "name":"test", "fields":[{"name": "a", "aliases": ["b.c"], "type": "int"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := avro.Parse(tt.schema)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAliases, schema.(*avro.RecordSchema).Fields()[0].Aliases())
+		})
+	}
+}
+
 func TestEnumSchema(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -517,6 +626,16 @@ func TestEnumSchema(t *testing.T) {
 			schema:  `{"type":"enum", "name":"test", "namespace": "org.hamba.avro", "symbols":[1]}`,
 			wantErr: true,
 		},
+		{
+			name:    "Invalid Default",
+			schema:  `{"type":"enum", "name":"test", "namespace": "org.hamba.avro", "symbols":["TEST"], "default": "FOO"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Default Type",
+			schema:  `{"type":"enum", "name":"test", "namespace": "org.hamba.avro", "symbols":["TEST"], "default": 1}`,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -536,6 +655,17 @@ func TestEnumSchema(t *testing.T) {
 	}
 }
 
+func TestEnumSchema_Default(t *testing.T) {
+	schm := `{"type":"enum", "name":"test", "namespace": "org.hamba.avro", "symbols":["TEST", "UNKNOWN"], "default": "UNKNOWN"}`
+
+	s, err := avro.Parse(schm)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "UNKNOWN", s.(*avro.EnumSchema).Default())
+	assert.Nil(t, s.(*avro.EnumSchema).Prop("default"))
+	assert.Equal(t, `{"name":"org.hamba.avro.test","type":"enum","symbols":["TEST","UNKNOWN"]}`, s.String())
+}
+
 func TestEnumSchema_HandlesProps(t *testing.T) {
 	schm := `{"type":"enum", "name":"test", "namespace": "org.hamba.avro", "symbols":["TEST"], "foo":"bar"}`
 
@@ -864,6 +994,34 @@ func TestSchema_LogicalTypes(t *testing.T) {
 			wantLogical:     true,
 			wantLogicalType: avro.TimestampMicros,
 		},
+		{
+			name:            "Timestamp Nanos",
+			schema:          `{"type": "long", "logicalType": "timestamp-nanos"}`,
+			wantType:        avro.Long,
+			wantLogical:     true,
+			wantLogicalType: avro.TimestampNanos,
+		},
+		{
+			name:            "Local Timestamp Millis",
+			schema:          `{"type": "long", "logicalType": "local-timestamp-millis"}`,
+			wantType:        avro.Long,
+			wantLogical:     true,
+			wantLogicalType: avro.LocalTimestampMillis,
+		},
+		{
+			name:            "Local Timestamp Micros",
+			schema:          `{"type": "long", "logicalType": "local-timestamp-micros"}`,
+			wantType:        avro.Long,
+			wantLogical:     true,
+			wantLogicalType: avro.LocalTimestampMicros,
+		},
+		{
+			name:            "Local Timestamp Nanos",
+			schema:          `{"type": "long", "logicalType": "local-timestamp-nanos"}`,
+			wantType:        avro.Long,
+			wantLogical:     true,
+			wantLogicalType: avro.LocalTimestampNanos,
+		},
 		{
 			name:            "UUID",
 			schema:          `{"type": "string", "logicalType": "uuid"}`,
@@ -884,6 +1042,19 @@ func TestSchema_LogicalTypes(t *testing.T) {
 			wantType:    avro.Fixed,
 			wantLogical: false,
 		},
+		{
+			name:            "Fixed UUID",
+			schema:          `{"type": "fixed", "name":"test", "size": 16, "logicalType": "uuid"}`,
+			wantType:        avro.Fixed,
+			wantLogical:     true,
+			wantLogicalType: avro.UUID,
+		},
+		{
+			name:        "Invalid Fixed UUID",
+			schema:      `{"type": "fixed", "name":"test", "size": 12, "logicalType": "uuid"}`,
+			wantType:    avro.Fixed,
+			wantLogical: false,
+		},
 		{
 			name:            "Bytes Decimal",
 			schema:          `{"type": "bytes", "logicalType": "decimal", "precision": 4, "scale": 2}`,
@@ -0,0 +1,64 @@
+package soe_test
+
+import (
+	"log"
+
+	"github.com/hamba/avro"
+	"github.com/hamba/avro/soe"
+)
+
+func ExampleMarshal() {
+	schema := avro.MustParse(`{
+	    "type": "record",
+	    "name": "simple",
+	    "namespace": "org.hamba.avro",
+	    "fields" : [
+	        {"name": "a", "type": "long"},
+	        {"name": "b", "type": "string"}
+	    ]
+	}`)
+
+	type SimpleRecord struct {
+		A int64  `avro:"a"`
+		B string `avro:"b"`
+	}
+
+	data, err := soe.Marshal(schema, SimpleRecord{A: 27, B: "foo"})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Store the self-describing data
+	_ = data
+}
+
+func ExampleUnmarshal() {
+	schema := avro.MustParse(`{
+	    "type": "record",
+	    "name": "simple",
+	    "namespace": "org.hamba.avro",
+	    "fields" : [
+	        {"name": "a", "type": "long"},
+	        {"name": "b", "type": "string"}
+	    ]
+	}`)
+
+	type SimpleRecord struct {
+		A int64  `avro:"a"`
+		B string `avro:"b"`
+	}
+
+	store, err := soe.NewMemoryStore(schema)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	data := []byte{} // Single object encoded data
+
+	var record SimpleRecord
+	if err := soe.Unmarshal(store, data, &record); err != nil {
+		log.Fatal(err)
+	}
+
+	// Do something with the data
+}
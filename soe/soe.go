@@ -0,0 +1,121 @@
+/*
+Package soe implements encoding and decoding of Avro single object encoded values as defined by the Avro specification.
+
+A single object encoded value is the 2 byte marker 0xC3 0x01, followed by the 8 byte little-endian
+CRC-64-AVRO fingerprint of the writer schema, followed by the Avro binary encoding of the value.
+
+See the Avro specification for an understanding of Avro: http://avro.apache.org/docs/current/
+*/
+package soe
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/hamba/avro"
+	"github.com/modern-go/concurrent"
+)
+
+// HeaderSize is the size of a single object encoding header in bytes.
+const HeaderSize = 10
+
+var magicBytes = [2]byte{0xC3, 0x01}
+
+// SchemaStore resolves a CRC-64-AVRO fingerprint to its schema.
+type SchemaStore interface {
+	// Schema returns the schema with the given fingerprint.
+	Schema(fingerprint uint64) (avro.Schema, error)
+}
+
+// MemoryStore is an in memory schema store.
+type MemoryStore struct {
+	schemas concurrent.Map // map[uint64]avro.Schema
+}
+
+// NewMemoryStore returns a schema store containing the given schemas.
+func NewMemoryStore(schemas ...avro.Schema) (*MemoryStore, error) {
+	s := &MemoryStore{}
+	for _, schema := range schemas {
+		if err := s.Add(schema); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Add adds a schema to the store.
+func (s *MemoryStore) Add(schema avro.Schema) error {
+	fp, err := Fingerprint(schema)
+	if err != nil {
+		return err
+	}
+
+	s.schemas.Store(fp, schema)
+	return nil
+}
+
+// Schema returns the schema with the given fingerprint.
+func (s *MemoryStore) Schema(fingerprint uint64) (avro.Schema, error) {
+	if v, ok := s.schemas.Load(fingerprint); ok {
+		return v.(avro.Schema), nil
+	}
+
+	return nil, fmt.Errorf("soe: schema with fingerprint %#016x not found", fingerprint)
+}
+
+// Fingerprint returns the CRC-64-AVRO fingerprint of the schema.
+func Fingerprint(schema avro.Schema) (uint64, error) {
+	fp, err := schema.FingerprintUsing(avro.CRC64Avro)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(fp), nil
+}
+
+// Marshal returns the single object encoding of v.
+func Marshal(schema avro.Schema, v interface{}) ([]byte, error) {
+	fp, err := Fingerprint(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := avro.Marshal(schema, v)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, HeaderSize, HeaderSize+len(data))
+	copy(b, magicBytes[:])
+	binary.LittleEndian.PutUint64(b[2:], fp)
+
+	return append(b, data...), nil
+}
+
+// Unmarshal parses the single object encoded data and stores the result in the value pointed to by v.
+// The writer schema is resolved from the store using the fingerprint in the data header.
+func Unmarshal(store SchemaStore, data []byte, v interface{}) error {
+	fp, payload, err := ParseHeader(data)
+	if err != nil {
+		return err
+	}
+
+	schema, err := store.Schema(fp)
+	if err != nil {
+		return err
+	}
+
+	return avro.Unmarshal(schema, payload, v)
+}
+
+// ParseHeader returns the writer schema fingerprint and the Avro encoded payload of
+// the single object encoded data.
+func ParseHeader(data []byte) (uint64, []byte, error) {
+	if len(data) < HeaderSize || data[0] != magicBytes[0] || data[1] != magicBytes[1] {
+		return 0, nil, errors.New("soe: invalid single object encoding header")
+	}
+
+	return binary.LittleEndian.Uint64(data[2:HeaderSize]), data[HeaderSize:], nil
+}
@@ -0,0 +1,104 @@
+package soe_test
+
+import (
+	"testing"
+
+	"github.com/hamba/avro"
+	"github.com/hamba/avro/soe"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestRecord struct {
+	A int64  `avro:"a"`
+	B string `avro:"b"`
+}
+
+var schema = avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+	    {"name": "b", "type": "string"}
+	]
+}`)
+
+func TestFingerprint(t *testing.T) {
+	got, err := soe.Fingerprint(avro.MustParse(`"int"`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0x7275d51a3f395c8f), got)
+}
+
+func TestMarshal(t *testing.T) {
+	got, err := soe.Marshal(schema, TestRecord{A: 27, B: "foo"})
+
+	assert.NoError(t, err)
+	fp, _ := schema.FingerprintUsing(avro.CRC64Avro)
+	want := []byte{0xc3, 0x01, fp[7], fp[6], fp[5], fp[4], fp[3], fp[2], fp[1], fp[0], 0x36, 0x06, 0x66, 0x6f, 0x6f}
+	assert.Equal(t, want, got)
+}
+
+func TestMarshal_Error(t *testing.T) {
+	_, err := soe.Marshal(schema, "test")
+
+	assert.Error(t, err)
+}
+
+func TestUnmarshal(t *testing.T) {
+	store, err := soe.NewMemoryStore(schema)
+	assert.NoError(t, err)
+	data, err := soe.Marshal(schema, TestRecord{A: 27, B: "foo"})
+	assert.NoError(t, err)
+
+	var got TestRecord
+	err = soe.Unmarshal(store, data, &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, TestRecord{A: 27, B: "foo"}, got)
+}
+
+func TestUnmarshal_InvalidHeader(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "Short",
+			data: []byte{0xc3, 0x01, 0x00},
+		},
+		{
+			name: "Invalid Magic",
+			data: []byte{0xc3, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x36},
+		},
+	}
+
+	store, _ := soe.NewMemoryStore(schema)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got TestRecord
+			err := soe.Unmarshal(store, tt.data, &got)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestUnmarshal_UnknownSchema(t *testing.T) {
+	store, _ := soe.NewMemoryStore()
+	data, err := soe.Marshal(schema, TestRecord{A: 27, B: "foo"})
+	assert.NoError(t, err)
+
+	var got TestRecord
+	err = soe.Unmarshal(store, data, &got)
+
+	assert.Error(t, err)
+}
+
+func TestParseHeader(t *testing.T) {
+	fp, payload, err := soe.ParseHeader([]byte{0xc3, 0x01, 0x8f, 0x5c, 0x39, 0x3f, 0x1a, 0xd5, 0x75, 0x72, 0x36})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0x7275d51a3f395c8f), fp)
+	assert.Equal(t, []byte{0x36}, payload)
+}
@@ -0,0 +1,11 @@
+package avro
+
+// LogicalDuration is an amount of time defined by a number of months, days and milliseconds.
+//
+// It is the Go representation of the duration logical type. The fields are independent,
+// a month is not a fixed number of days and a day is not a fixed number of milliseconds.
+type LogicalDuration struct {
+	Months       uint32
+	Days         uint32
+	Milliseconds uint32
+}